@@ -20,6 +20,10 @@ func (w *SizeStatWriter) Close() error {
 	return common.Close(w.Writer)
 }
 
+func (w *SizeStatWriter) CloseWithError(err error) error {
+	return common.CloseWithError(w.Writer, err)
+}
+
 func (w *SizeStatWriter) Interrupt() {
 	common.Interrupt(w.Writer)
 }
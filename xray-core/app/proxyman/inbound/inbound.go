@@ -177,6 +177,10 @@ func NewHandler(ctx context.Context, config *core.InboundHandlerConfig) (inbound
 	if streamSettings != nil && streamSettings.ProtocolName == "splithttp" {
 		ctx = session.ContextWithAllowedNetwork(ctx, net.Network_UDP)
 	}
+	ctx = session.ContextWithInboundHandler(ctx, &session.InboundHandler{
+		Tag:          tag,
+		SecurityType: streamSettings.GetSecurityType(),
+	})
 
 	return NewAlwaysOnInboundHandler(ctx, tag, receiverSettings, proxySettings)
 }
@@ -27,6 +27,23 @@ func Close(obj interface{}) error {
 	return nil
 }
 
+// ErrorClosable is the interface for objects that can be closed with an error reported to the reading side.
+//
+// xray:api:beta
+type ErrorClosable interface {
+	CloseWithError(err error) error
+}
+
+// CloseWithError calls CloseWithError() if obj implements ErrorClosable interface, or Close() if the object implements Closable interface.
+//
+// xray:api:beta
+func CloseWithError(obj interface{}, err error) error {
+	if c, ok := obj.(ErrorClosable); ok {
+		return c.CloseWithError(err)
+	}
+	return Close(obj)
+}
+
 // Interrupt calls Interrupt() if object implements Interruptible interface, or Close() if the object implements Closable interface.
 //
 // xray:api:beta
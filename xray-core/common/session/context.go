@@ -26,6 +26,7 @@ const (
 	fullHandlerKey            ctx.SessionKey = 10 // outbound gets full handler
 	mitmAlpn11Key             ctx.SessionKey = 11 // used by TLS dialer
 	mitmServerNameKey         ctx.SessionKey = 12 // used by TLS dialer
+	inboundHandlerKey         ctx.SessionKey = 13 // used by inbound proxies to see the handler they are created for
 )
 
 func ContextWithInbound(ctx context.Context, inbound *Inbound) context.Context {
@@ -97,6 +98,17 @@ func SockoptFromContext(ctx context.Context) *Sockopt {
 	return nil
 }
 
+func ContextWithInboundHandler(ctx context.Context, h *InboundHandler) context.Context {
+	return context.WithValue(ctx, inboundHandlerKey, h)
+}
+
+func InboundHandlerFromContext(ctx context.Context) *InboundHandler {
+	if h, ok := ctx.Value(inboundHandlerKey).(*InboundHandler); ok {
+		return h
+	}
+	return nil
+}
+
 func GetForcedOutboundTagFromContext(ctx context.Context) string {
 	if ContentFromContext(ctx) == nil {
 		return ""
@@ -105,6 +105,15 @@ type Sockopt struct {
 	Mark int32
 }
 
+// InboundHandler describes the inbound handler an inbound proxy is created for.
+type InboundHandler struct {
+	// Tag of the inbound handler.
+	Tag string
+	// SecurityType is the type of streamSettings security, e.g. TLS or
+	// REALITY. Empty if the stream adds none.
+	SecurityType string
+}
+
 // SetAttribute attaches additional string attributes to content.
 func (c *Content) SetAttribute(name string, value string) {
 	if c.Attributes == nil {
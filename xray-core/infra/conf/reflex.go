@@ -24,14 +24,32 @@ type ReflexECHConfig struct {
 	Insecure   bool   `json:"insecure"`
 }
 
+type ReflexSpeedTestConfig struct {
+	Enabled  bool   `json:"enabled"`
+	MaxBytes uint64 `json:"maxBytes"`
+	// Interval is the minimum time between two tests by the same user, in
+	// seconds.
+	Interval uint32 `json:"interval"`
+}
+
 type ReflexInboundConfig struct {
-	Clients  []*ReflexUserConfig   `json:"clients"`
-	Fallback *ReflexFallbackConfig `json:"fallback"`
-	ECH      *ReflexECHConfig      `json:"ech"`
+	Clients           []*ReflexUserConfig    `json:"clients"`
+	Fallback          *ReflexFallbackConfig  `json:"fallback"`
+	ECH               *ReflexECHConfig       `json:"ech"`
+	CryptoWorkers     uint32                 `json:"cryptoWorkers"`
+	MaxTimestampDrift uint32                 `json:"maxTimestampDrift"`
+	SpeedTest         *ReflexSpeedTestConfig `json:"speedTest"`
 }
 
 func (c *ReflexInboundConfig) Build() (proto.Message, error) {
-	config := &reflex.InboundConfig{}
+	if c.MaxTimestampDrift > reflex.MaxTimestampDriftLimit {
+		return nil, errors.New("Reflex inbound: maxTimestampDrift must not exceed ", reflex.MaxTimestampDriftLimit, " seconds")
+	}
+
+	config := &reflex.InboundConfig{
+		CryptoWorkers:     c.CryptoWorkers,
+		MaxTimestampDrift: c.MaxTimestampDrift,
+	}
 
 	for _, rawUser := range c.Clients {
 		if rawUser.ID == "" {
@@ -43,6 +61,14 @@ func (c *ReflexInboundConfig) Build() (proto.Message, error) {
 		})
 	}
 
+	if c.SpeedTest != nil {
+		config.SpeedTest = &reflex.SpeedTestSettings{
+			Enabled:  c.SpeedTest.Enabled,
+			MaxBytes: c.SpeedTest.MaxBytes,
+			Interval: c.SpeedTest.Interval,
+		}
+	}
+
 	if c.Fallback != nil {
 		config.Fallback = &reflex.Fallback{
 			Dest: c.Fallback.Dest,
@@ -65,11 +91,12 @@ func (c *ReflexInboundConfig) Build() (proto.Message, error) {
 }
 
 type ReflexOutboundConfig struct {
-	Address string          `json:"address"`
-	Port    uint32          `json:"port"`
-	ID      string          `json:"id"`
-	Policy  string          `json:"policy"`
-	ECH     *ReflexECHConfig `json:"ech"`
+	Address    string           `json:"address"`
+	Port       uint32           `json:"port"`
+	ID         string           `json:"id"`
+	Policy     string           `json:"policy"`
+	ECH        *ReflexECHConfig `json:"ech"`
+	RangeHints bool             `json:"rangeHints"`
 }
 
 func (c *ReflexOutboundConfig) Build() (proto.Message, error) {
@@ -84,10 +111,11 @@ func (c *ReflexOutboundConfig) Build() (proto.Message, error) {
 	}
 
 	outConfig := &reflex.OutboundConfig{
-		Address: c.Address,
-		Port:    c.Port,
-		Id:      c.ID,
-		Policy:  c.Policy,
+		Address:    c.Address,
+		Port:       c.Port,
+		Id:         c.ID,
+		Policy:     c.Policy,
+		RangeHints: c.RangeHints,
 	}
 
 	if c.ECH != nil && c.ECH.Enabled {
@@ -0,0 +1,72 @@
+package api
+
+import (
+	"github.com/xtls/xray-core/main/commands/base"
+	reflexService "github.com/xtls/xray-core/proxy/reflex/command"
+)
+
+var cmdReflexSpeedTest = &base.Command{
+	CustomFlags: true,
+	UsageLine:   "{{.Exec}} api reflexspeedtest [--server=127.0.0.1:8080] -outbound <tag> [-size 10]",
+	Short:       "Measure Reflex tunnel throughput",
+	Long: `
+Run an in-band speed test through a Reflex outbound. The server streams
+test data frames using the same framing and morph profile as regular
+traffic, and the client measures how fast they arrive.
+
+The test runs on the outbound's most recent session that is carrying
+traffic, next to that traffic. Only when no session is open is a new one
+opened for the test. Servers without mid-session speed tests drop the
+session on the request.
+
+Requires ReflexSpeedTestService to be enabled in the API config, and
+speedTest to be enabled on the server's Reflex inbound. The server allows
+each user one test per interval (in seconds) and may cap the size.
+
+Arguments:
+
+	-s, -server <server:port>
+		The API server address. Default 127.0.0.1:8080
+
+	-t, -timeout <seconds>
+		Timeout in seconds for calling API. Default 60
+
+	-outbound <tag>
+		Tag of the Reflex outbound to test.
+
+	-size <MB>
+		Megabytes of test data to download. Default 10
+
+Example:
+
+	{{.Exec}} {{.LongName}} --server=127.0.0.1:8080 -outbound reflex-out -size 50
+`,
+	Run: executeReflexSpeedTest,
+}
+
+func executeReflexSpeedTest(cmd *base.Command, args []string) {
+	setSharedFlags(cmd)
+	// Transferring the padding takes much longer than a regular API call.
+	apiTimeout = 60
+	tag := cmd.Flag.String("outbound", "", "")
+	size := cmd.Flag.Uint64("size", 10, "")
+	cmd.Flag.Parse(args)
+
+	if *tag == "" {
+		base.Fatalf("an outbound tag is required")
+	}
+
+	conn, ctx, close := dialAPIServer()
+	defer close()
+
+	client := reflexService.NewReflexSpeedTestServiceClient(conn)
+	r := &reflexService.SpeedTestRequest{
+		OutboundTag: *tag,
+		Size:        *size << 20,
+	}
+	resp, err := client.SpeedTest(ctx, r)
+	if err != nil {
+		base.Fatalf("failed to run speed test: %s", err)
+	}
+	showJSONResponse(resp)
+}
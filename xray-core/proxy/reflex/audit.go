@@ -0,0 +1,110 @@
+package reflex
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/xtls/xray-core/common/errors"
+	"github.com/xtls/xray-core/common/session"
+)
+
+// StealthScoreCounter returns the name of the stats counter holding the
+// stealth score of the inbound with the given tag.
+func StealthScoreCounter(tag string) string {
+	return "inbound>>>" + tag + ">>>reflex>>>stealth_score"
+}
+
+// AuditFinding is a risky setting detected by AuditInbound.
+type AuditFinding struct {
+	Code    string
+	Message string
+	// Penalty is subtracted from the stealth score.
+	Penalty int
+}
+
+// AuditReport lists the findings for an inbound configuration together with
+// an aggregate stealth score from 0 (easily fingerprinted) to 100.
+type AuditReport struct {
+	Findings []AuditFinding
+	Score    int
+	// Unchecked lists the codes of checks that were skipped because the
+	// settings they need were not available. They do not affect the score.
+	Unchecked []string
+}
+
+// AuditInbound flags configurations that make a Reflex server easier to
+// detect, so that operators can converge on safe setups without having to
+// know every knob. handler describes the inbound handler the config belongs
+// to; when it is nil, checks that depend on its streamSettings are skipped
+// and listed in Unchecked.
+func AuditInbound(config *InboundConfig, handler *session.InboundHandler) *AuditReport {
+	report := &AuditReport{}
+
+	if config.GetFallback() == nil {
+		report.add(AuditFinding{
+			Code:    "no-fallback",
+			Message: "no fallback configured: probes that fail the handshake are dropped instead of being served like a regular web server",
+			Penalty: 30,
+		})
+	}
+
+	unmorphed := 0
+	for _, client := range config.GetClients() {
+		if _, ok := LookupProfile(client.GetPolicy()); !ok {
+			unmorphed++
+		}
+	}
+	if unmorphed > 0 {
+		report.add(AuditFinding{
+			Code:    "morph-disabled",
+			Message: fmt.Sprintf("%d of %d clients have no known morph profile: their frame sizes and timing are not shaped", unmorphed, len(config.GetClients())),
+			Penalty: 20,
+		})
+	}
+
+	if drift := int64(config.GetMaxTimestampDrift()); drift > MaxTimestampDrift {
+		report.add(AuditFinding{
+			Code:    "drift-widened",
+			Message: fmt.Sprintf("timestamp drift window widened to %ds (default %ds): recorded handshakes stay replayable for longer", drift, MaxTimestampDrift),
+			Penalty: 15,
+		})
+	}
+
+	if ech := config.GetEch(); ech == nil || !ech.GetEnabled() {
+		switch {
+		case handler == nil:
+			report.Unchecked = append(report.Unchecked, "ech-disabled")
+		case handler.SecurityType == "":
+			report.add(AuditFinding{
+				Code:    "ech-disabled",
+				Message: "ECH is disabled and streamSettings add no TLS or REALITY: the Reflex handshake is exposed on bare TCP",
+				Penalty: 25,
+			})
+		}
+	}
+
+	report.Score = 100
+	for _, f := range report.Findings {
+		report.Score -= f.Penalty
+	}
+	if report.Score < 0 {
+		report.Score = 0
+	}
+	return report
+}
+
+func (r *AuditReport) add(f AuditFinding) {
+	r.Findings = append(r.Findings, f)
+}
+
+// Log writes one warning per finding, a note per unchecked setting and the
+// aggregate score.
+func (r *AuditReport) Log(ctx context.Context) {
+	for _, f := range r.Findings {
+		errors.LogWarning(ctx, "reflex audit [", f.Code, "] ", f.Message, " (-", f.Penalty, ")")
+	}
+	for _, code := range r.Unchecked {
+		errors.LogInfo(ctx, "reflex audit [", code, "] not checked: the inbound handler's streamSettings are unknown")
+	}
+	errors.LogInfo(ctx, "reflex audit: stealth score ", r.Score, "/100")
+}
@@ -0,0 +1,97 @@
+package reflex
+
+import (
+	"testing"
+
+	"github.com/xtls/xray-core/common/session"
+)
+
+// bareHandler is an inbound handler whose streamSettings add no security.
+var bareHandler = &session.InboundHandler{Tag: "reflex-in"}
+
+func safeInboundConfig() *InboundConfig {
+	return &InboundConfig{
+		Clients:  []*User{{Id: "client", Policy: "youtube"}},
+		Fallback: &Fallback{Dest: 80},
+		Ech:      &ECHSettings{Enabled: true},
+	}
+}
+
+func hasFinding(report *AuditReport, code string) bool {
+	for _, f := range report.Findings {
+		if f.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+func TestAuditSafeConfig(t *testing.T) {
+	report := AuditInbound(safeInboundConfig(), bareHandler)
+	if len(report.Findings) != 0 || report.Score != 100 {
+		t.Fatalf("expected a clean report, got %+v", report)
+	}
+}
+
+func TestAuditRiskySettings(t *testing.T) {
+	tests := []struct {
+		code   string
+		modify func(*InboundConfig)
+	}{
+		{"no-fallback", func(c *InboundConfig) { c.Fallback = nil }},
+		{"morph-disabled", func(c *InboundConfig) { c.Clients[0].Policy = "" }},
+		{"drift-widened", func(c *InboundConfig) { c.MaxTimestampDrift = 600 }},
+		{"ech-disabled", func(c *InboundConfig) { c.Ech = nil }},
+	}
+
+	for _, tt := range tests {
+		config := safeInboundConfig()
+		tt.modify(config)
+		report := AuditInbound(config, bareHandler)
+		if len(report.Findings) != 1 || !hasFinding(report, tt.code) {
+			t.Fatalf("expected only %s, got %+v", tt.code, report.Findings)
+		}
+		if report.Score != 100-report.Findings[0].Penalty {
+			t.Fatalf("%s: unexpected score %d", tt.code, report.Score)
+		}
+	}
+}
+
+func TestAuditAllFindings(t *testing.T) {
+	config := &InboundConfig{
+		Clients:           []*User{{Id: "client"}},
+		MaxTimestampDrift: 600,
+	}
+	report := AuditInbound(config, bareHandler)
+	if len(report.Findings) != 4 {
+		t.Fatalf("expected 4 findings, got %+v", report.Findings)
+	}
+	if report.Score != 10 {
+		t.Fatalf("expected score 10, got %d", report.Score)
+	}
+}
+
+func TestAuditStreamSecurity(t *testing.T) {
+	config := safeInboundConfig()
+	config.Ech = nil
+
+	// TLS from streamSettings covers the handshake when ECH is off.
+	handler := &session.InboundHandler{Tag: "reflex-in", SecurityType: "xray.transport.internet.tls.Config"}
+	if report := AuditInbound(config, handler); len(report.Findings) != 0 {
+		t.Fatalf("expected no findings, got %+v", report.Findings)
+	}
+}
+
+func TestAuditUnknownHandler(t *testing.T) {
+	config := safeInboundConfig()
+	config.Ech = nil
+
+	// Without the handler, streamSettings are unknown and ECH is not judged.
+	report := AuditInbound(config, nil)
+	if len(report.Findings) != 0 || report.Score != 100 {
+		t.Fatalf("expected no findings, got %+v", report.Findings)
+	}
+	if len(report.Unchecked) != 1 || report.Unchecked[0] != "ech-disabled" {
+		t.Fatalf("expected ech-disabled to be unchecked, got %v", report.Unchecked)
+	}
+}
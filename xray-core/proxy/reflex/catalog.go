@@ -0,0 +1,210 @@
+package reflex
+
+import (
+	"encoding/json"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/xtls/xray-core/common/errors"
+)
+
+// MaxProfileDelay bounds the delays of custom profiles. Morphing sleeps
+// for the sampled delay before every frame, so a larger value would stall
+// every session using the profile.
+const MaxProfileDelay = 2 * time.Second
+
+// The profile catalog is made of the builtin profiles plus custom profiles
+// pushed at runtime, so traffic-analysis teams can iterate on shaping
+// without restarting the server. Builtin profiles cannot be replaced.
+var (
+	customProfilesMu sync.RWMutex
+	customProfiles   = make(map[string]*TrafficProfile)
+)
+
+// ProfileSpec is the JSON representation of a traffic profile.
+type ProfileSpec struct {
+	ID          string           `json:"id"`
+	Name        string           `json:"name"`
+	Builtin     bool             `json:"builtin"`
+	PacketSizes []PacketSizeSpec `json:"packetSizes"`
+	Delays      []DelaySpec      `json:"delays"`
+}
+
+// PacketSizeSpec is the JSON form of PacketSizeDist.
+type PacketSizeSpec struct {
+	Size   int     `json:"size"`
+	Weight float64 `json:"weight"`
+}
+
+// DelaySpec is the JSON form of DelayDist, with the delay in milliseconds.
+type DelaySpec struct {
+	DelayMs int64   `json:"delayMs"`
+	Weight  float64 `json:"weight"`
+}
+
+// LookupProfile returns the builtin or custom profile with the given id.
+func LookupProfile(id string) (*TrafficProfile, bool) {
+	if p, ok := BuiltinProfiles[id]; ok {
+		return p, true
+	}
+	customProfilesMu.RLock()
+	defer customProfilesMu.RUnlock()
+	p, ok := customProfiles[id]
+	return p, ok
+}
+
+// IsBuiltinProfile reports whether id names a builtin profile.
+func IsBuiltinProfile(id string) bool {
+	_, ok := BuiltinProfiles[id]
+	return ok
+}
+
+// ProfileIDs returns the ids of all profiles in the catalog, sorted.
+func ProfileIDs() []string {
+	customProfilesMu.RLock()
+	ids := make([]string, 0, len(BuiltinProfiles)+len(customProfiles))
+	for id := range customProfiles {
+		ids = append(ids, id)
+	}
+	customProfilesMu.RUnlock()
+
+	for id := range BuiltinProfiles {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// RegisterProfile adds or replaces a custom profile. Sessions that already
+// use a previous version of the profile keep it until they end.
+func RegisterProfile(id string, profile *TrafficProfile) error {
+	if id == "" {
+		return errors.New("profile id is empty")
+	}
+	if IsBuiltinProfile(id) {
+		return errors.New("cannot replace builtin profile ", id)
+	}
+	if err := validateProfile(profile); err != nil {
+		return errors.New("invalid profile ", id).Base(err)
+	}
+
+	customProfilesMu.Lock()
+	defer customProfilesMu.Unlock()
+	customProfiles[id] = profile
+	return nil
+}
+
+// RemoveProfile deletes a custom profile. Sessions already using it keep
+// their copy until they end. Builtin profiles cannot be removed.
+func RemoveProfile(id string) error {
+	if IsBuiltinProfile(id) {
+		return errors.New("cannot remove builtin profile ", id)
+	}
+
+	customProfilesMu.Lock()
+	defer customProfilesMu.Unlock()
+	if _, ok := customProfiles[id]; !ok {
+		return errors.New("unknown profile ", id)
+	}
+	delete(customProfiles, id)
+	return nil
+}
+
+func validateProfile(p *TrafficProfile) error {
+	if p == nil {
+		return errors.New("profile is nil")
+	}
+	if len(p.PacketSizes) == 0 {
+		return errors.New("no packet sizes")
+	}
+	if len(p.Delays) == 0 {
+		return errors.New("no delays")
+	}
+
+	var total float64
+	for _, d := range p.PacketSizes {
+		if d.Size <= 0 || d.Size > math.MaxUint16 {
+			return errors.New("packet size out of range: ", d.Size)
+		}
+		if d.Weight <= 0 {
+			return errors.New("packet size weight must be positive")
+		}
+		total += d.Weight
+	}
+	if math.Abs(total-1) > 0.01 {
+		return errors.New("packet size weights must sum to 1, got ", total)
+	}
+
+	total = 0
+	for _, d := range p.Delays {
+		if d.Delay < 0 || d.Delay > MaxProfileDelay {
+			return errors.New("delay out of range: ", d.Delay)
+		}
+		if d.Weight <= 0 {
+			return errors.New("delay weight must be positive")
+		}
+		total += d.Weight
+	}
+	if math.Abs(total-1) > 0.01 {
+		return errors.New("delay weights must sum to 1, got ", total)
+	}
+	return nil
+}
+
+// SpecFromProfile converts a catalog profile to its JSON representation.
+func SpecFromProfile(id string, p *TrafficProfile) *ProfileSpec {
+	spec := &ProfileSpec{
+		ID:          id,
+		Name:        p.Name,
+		Builtin:     IsBuiltinProfile(id),
+		PacketSizes: make([]PacketSizeSpec, 0, len(p.PacketSizes)),
+		Delays:      make([]DelaySpec, 0, len(p.Delays)),
+	}
+	for _, d := range p.PacketSizes {
+		spec.PacketSizes = append(spec.PacketSizes, PacketSizeSpec{Size: d.Size, Weight: d.Weight})
+	}
+	for _, d := range p.Delays {
+		spec.Delays = append(spec.Delays, DelaySpec{DelayMs: d.Delay.Milliseconds(), Weight: d.Weight})
+	}
+	return spec
+}
+
+// Profile builds a TrafficProfile from the spec.
+func (s *ProfileSpec) Profile() *TrafficProfile {
+	p := &TrafficProfile{
+		Name:        s.Name,
+		PacketSizes: make([]PacketSizeDist, 0, len(s.PacketSizes)),
+		Delays:      make([]DelayDist, 0, len(s.Delays)),
+	}
+	for _, d := range s.PacketSizes {
+		p.PacketSizes = append(p.PacketSizes, PacketSizeDist{Size: d.Size, Weight: d.Weight})
+	}
+	for _, d := range s.Delays {
+		p.Delays = append(p.Delays, DelayDist{Delay: time.Duration(d.DelayMs) * time.Millisecond, Weight: d.Weight})
+	}
+	return p
+}
+
+// MarshalProfileJSON returns the catalog profile with the given id as JSON.
+func MarshalProfileJSON(id string) ([]byte, error) {
+	p, ok := LookupProfile(id)
+	if !ok {
+		return nil, errors.New("unknown profile ", id)
+	}
+	return json.MarshalIndent(SpecFromProfile(id, p), "", "  ")
+}
+
+// RegisterProfileJSON parses a ProfileSpec and registers it as a custom
+// profile. It returns the id of the registered profile.
+func RegisterProfileJSON(data []byte) (string, error) {
+	spec := new(ProfileSpec)
+	if err := json.Unmarshal(data, spec); err != nil {
+		return "", errors.New("failed to parse profile JSON").Base(err)
+	}
+	if err := RegisterProfile(spec.ID, spec.Profile()); err != nil {
+		return "", err
+	}
+	return spec.ID, nil
+}
@@ -0,0 +1,138 @@
+package reflex
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func testCustomProfile() *TrafficProfile {
+	return &TrafficProfile{
+		Name: "Custom",
+		PacketSizes: []PacketSizeDist{
+			{Size: 300, Weight: 0.6},
+			{Size: 1200, Weight: 0.4},
+		},
+		Delays: []DelayDist{
+			{Delay: 10 * time.Millisecond, Weight: 1},
+		},
+	}
+}
+
+// registerTestProfile registers a custom profile and removes it when the
+// test ends, so tests do not leak profiles into the global catalog.
+func registerTestProfile(t *testing.T, id string, profile *TrafficProfile) {
+	t.Helper()
+	if err := RegisterProfile(id, profile); err != nil {
+		t.Fatalf("RegisterProfile failed: %v", err)
+	}
+	t.Cleanup(func() { _ = RemoveProfile(id) })
+}
+
+func TestRegisterProfileUsedByMorph(t *testing.T) {
+	registerTestProfile(t, "catalog-custom", testCustomProfile())
+
+	morph := NewTrafficMorph("catalog-custom")
+	if morph == nil {
+		t.Fatal("expected morph for custom profile")
+	}
+	if morph.Profile.Name != "Custom" {
+		t.Fatalf("unexpected profile %q", morph.Profile.Name)
+	}
+
+	found := false
+	for _, id := range ProfileIDs() {
+		if id == "catalog-custom" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatal("custom profile missing from ProfileIDs")
+	}
+}
+
+func TestRemoveProfile(t *testing.T) {
+	if err := RegisterProfile("catalog-removed", testCustomProfile()); err != nil {
+		t.Fatalf("RegisterProfile failed: %v", err)
+	}
+	if err := RemoveProfile("catalog-removed"); err != nil {
+		t.Fatalf("RemoveProfile failed: %v", err)
+	}
+	if _, ok := LookupProfile("catalog-removed"); ok {
+		t.Fatal("removed profile still registered")
+	}
+	if err := RemoveProfile("catalog-removed"); err == nil {
+		t.Fatal("expected error when removing an unknown profile")
+	}
+	if err := RemoveProfile("youtube"); err == nil {
+		t.Fatal("expected error when removing a builtin profile")
+	}
+}
+
+func TestRegisterProfileRejectsBuiltin(t *testing.T) {
+	if err := RegisterProfile("youtube", testCustomProfile()); err == nil {
+		t.Fatal("expected error when replacing builtin profile")
+	}
+}
+
+func TestRegisterProfileValidation(t *testing.T) {
+	bad := testCustomProfile()
+	bad.PacketSizes[0].Weight = 0.1
+	if err := RegisterProfile("catalog-bad-weights", bad); err == nil {
+		t.Fatal("expected error for weights not summing to 1")
+	}
+
+	bad = testCustomProfile()
+	bad.Delays = nil
+	if err := RegisterProfile("catalog-no-delays", bad); err == nil {
+		t.Fatal("expected error for missing delays")
+	}
+
+	bad = testCustomProfile()
+	bad.PacketSizes[0].Size = 70000
+	if err := RegisterProfile("catalog-big-size", bad); err == nil {
+		t.Fatal("expected error for oversized packet")
+	}
+
+	bad = testCustomProfile()
+	bad.Delays[0].Delay = MaxProfileDelay + time.Millisecond
+	if err := RegisterProfile("catalog-long-delay", bad); err == nil {
+		t.Fatal("expected error for delay above MaxProfileDelay")
+	}
+}
+
+func TestProfileJSONRoundTrip(t *testing.T) {
+	data, err := MarshalProfileJSON("zoom")
+	if err != nil {
+		t.Fatalf("MarshalProfileJSON failed: %v", err)
+	}
+
+	// A builtin profile can be exported and pushed back under a new id.
+	var spec ProfileSpec
+	if err := json.Unmarshal(data, &spec); err != nil {
+		t.Fatalf("exported profile is not valid JSON: %v", err)
+	}
+	spec.ID = "zoom-copy"
+	if data, err = json.Marshal(&spec); err != nil {
+		t.Fatalf("json.Marshal failed: %v", err)
+	}
+	id, err := RegisterProfileJSON(data)
+	if err != nil {
+		t.Fatalf("RegisterProfileJSON failed: %v", err)
+	}
+	t.Cleanup(func() { _ = RemoveProfile(id) })
+
+	original, _ := LookupProfile("zoom")
+	copied, ok := LookupProfile(id)
+	if !ok {
+		t.Fatal("copied profile not registered")
+	}
+	if len(copied.PacketSizes) != len(original.PacketSizes) || len(copied.Delays) != len(original.Delays) {
+		t.Fatal("distributions differ after JSON round trip")
+	}
+	for i := range original.Delays {
+		if copied.Delays[i] != original.Delays[i] {
+			t.Fatalf("delay %d differs: %v vs %v", i, copied.Delays[i], original.Delays[i])
+		}
+	}
+}
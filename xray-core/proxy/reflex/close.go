@@ -0,0 +1,98 @@
+package reflex
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// CloseReason tells the peer why a session ended. It is carried as the first
+// byte of a CLOSE frame payload; an empty payload is a normal close.
+//
+// The server currently sends CloseReasonIdleTimeout, and
+// CloseReasonQuotaExceeded for rate-limited speed tests. GoAway and
+// AuthRevoked are reserved so that clients already decode them once the
+// server gains draining and live user removal.
+type CloseReason uint8
+
+const (
+	CloseReasonNormal        CloseReason = 0x00
+	CloseReasonGoAway        CloseReason = 0x01
+	CloseReasonQuotaExceeded CloseReason = 0x02
+	CloseReasonAuthRevoked   CloseReason = 0x03
+	CloseReasonIdleTimeout   CloseReason = 0x04
+
+	closePayloadSize = 3 // 1 byte reason + 2 bytes code
+)
+
+func (r CloseReason) String() string {
+	switch r {
+	case CloseReasonNormal:
+		return "normal close"
+	case CloseReasonGoAway:
+		return "server going away"
+	case CloseReasonQuotaExceeded:
+		return "quota exceeded"
+	case CloseReasonAuthRevoked:
+		return "authorization revoked"
+	case CloseReasonIdleTimeout:
+		return "idle timeout"
+	default:
+		return fmt.Sprintf("unknown close reason 0x%02x", uint8(r))
+	}
+}
+
+// CloseError is the final close reason reported by the server. Client
+// applications can match it with errors.As to show an actionable message
+// instead of a generic connection reset.
+type CloseError struct {
+	Reason CloseReason
+	// Code is an application-defined detail, e.g. the GOAWAY code.
+	Code uint16
+	// Offset is the number of uplink bytes the server had forwarded to the
+	// target before closing, see InterruptedError. It is only valid when
+	// OffsetKnown is set, which requires the client to have opted into range
+	// hints.
+	Offset      uint64
+	OffsetKnown bool
+}
+
+func (e *CloseError) Error() string {
+	if e.Code != 0 {
+		return fmt.Sprintf("reflex: session closed by server: %s (code %d)", e.Reason, e.Code)
+	}
+	return "reflex: session closed by server: " + e.Reason.String()
+}
+
+// EncodeClosePayload creates a CLOSE payload carrying the reason and code.
+func EncodeClosePayload(reason CloseReason, code uint16) []byte {
+	if reason == CloseReasonNormal && code == 0 {
+		return []byte{}
+	}
+	data := make([]byte, closePayloadSize)
+	data[0] = byte(reason)
+	binary.BigEndian.PutUint16(data[1:3], code)
+	return data
+}
+
+// ParseCloseFrame returns the close reason carried by a CLOSE frame, or nil
+// if the peer closed normally.
+func ParseCloseFrame(frame *Frame) *CloseError {
+	if frame.Type != FrameTypeClose || len(frame.Payload) == 0 {
+		return nil
+	}
+	closeErr := &CloseError{Reason: CloseReason(frame.Payload[0])}
+	if len(frame.Payload) >= closePayloadSize {
+		closeErr.Code = binary.BigEndian.Uint16(frame.Payload[1:3])
+	}
+	if closeErr.Reason == CloseReasonNormal && closeErr.Code == 0 {
+		return nil
+	}
+	return closeErr
+}
+
+// WriteCloseFrameWithReason sends a CLOSE frame telling the peer why the
+// session is ending.
+func (s *Session) WriteCloseFrameWithReason(writer io.Writer, reason CloseReason, code uint16) error {
+	return s.WriteFrame(writer, FrameTypeClose, EncodeClosePayload(reason, code))
+}
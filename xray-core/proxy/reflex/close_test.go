@@ -0,0 +1,96 @@
+package reflex
+
+import (
+	"bytes"
+	stderrors "errors"
+	"testing"
+
+	"github.com/xtls/xray-core/common/errors"
+)
+
+func TestCloseFrameWithReasonRoundTrip(t *testing.T) {
+	key := makeTestSessionKey()
+	writer, _ := NewSession(key)
+	reader, _ := NewSession(key)
+
+	var buf bytes.Buffer
+	if err := writer.WriteCloseFrameWithReason(&buf, CloseReasonGoAway, 7); err != nil {
+		t.Fatalf("WriteCloseFrameWithReason failed: %v", err)
+	}
+
+	frame, err := reader.ReadFrame(&buf)
+	if err != nil {
+		t.Fatalf("ReadFrame failed: %v", err)
+	}
+	closeErr := ParseCloseFrame(frame)
+	if closeErr == nil {
+		t.Fatal("expected a close reason")
+	}
+	if closeErr.Reason != CloseReasonGoAway || closeErr.Code != 7 {
+		t.Fatalf("unexpected close reason: %+v", closeErr)
+	}
+}
+
+func TestParseCloseFrameNormal(t *testing.T) {
+	key := makeTestSessionKey()
+	writer, _ := NewSession(key)
+	reader, _ := NewSession(key)
+
+	var buf bytes.Buffer
+	if err := writer.WriteCloseFrame(&buf); err != nil {
+		t.Fatalf("WriteCloseFrame failed: %v", err)
+	}
+	frame, err := reader.ReadFrame(&buf)
+	if err != nil {
+		t.Fatalf("ReadFrame failed: %v", err)
+	}
+	if closeErr := ParseCloseFrame(frame); closeErr != nil {
+		t.Fatalf("expected no close reason for a normal close, got %v", closeErr)
+	}
+
+	if len(EncodeClosePayload(CloseReasonNormal, 0)) != 0 {
+		t.Fatal("normal close should have an empty payload")
+	}
+}
+
+func TestParseCloseFrameIgnoresOtherTypes(t *testing.T) {
+	frame := &Frame{Type: FrameTypeData, Payload: []byte{byte(CloseReasonIdleTimeout), 0, 0}}
+	if ParseCloseFrame(frame) != nil {
+		t.Fatal("DATA frame must not be parsed as a close reason")
+	}
+}
+
+func TestCloseErrorUnwrapsThroughErrorChain(t *testing.T) {
+	wrapped := errors.New("connection ends").Base(&CloseError{Reason: CloseReasonQuotaExceeded})
+
+	var closeErr *CloseError
+	if !stderrors.As(wrapped, &closeErr) {
+		t.Fatal("expected errors.As to find *CloseError")
+	}
+	if closeErr.Reason != CloseReasonQuotaExceeded {
+		t.Fatalf("unexpected reason %v", closeErr.Reason)
+	}
+}
+
+func TestCloseReasonString(t *testing.T) {
+	reasons := []CloseReason{
+		CloseReasonNormal,
+		CloseReasonGoAway,
+		CloseReasonQuotaExceeded,
+		CloseReasonAuthRevoked,
+		CloseReasonIdleTimeout,
+	}
+	seen := make(map[string]bool)
+	for _, r := range reasons {
+		s := r.String()
+		if seen[s] {
+			t.Fatalf("duplicate description %q", s)
+		}
+		seen[s] = true
+	}
+
+	err := &CloseError{Reason: CloseReasonGoAway, Code: 2}
+	if err.Error() == (&CloseError{Reason: CloseReasonGoAway}).Error() {
+		t.Fatal("code should be part of the error message")
+	}
+}
@@ -17,6 +17,18 @@ const (
 	FrameTypePadding uint8 = 0x02
 	FrameTypeTiming  uint8 = 0x03
 	FrameTypeClose   uint8 = 0x04
+	// FrameTypeRangeHint is an optional control extension. A client sends it
+	// (empty) to opt in; the server replies with the forwarded uplink byte count.
+	FrameTypeRangeHint uint8 = 0x05
+	// FrameTypeSpeedTest opens an in-band throughput test when sent by the
+	// client, either as the first frame or in the middle of a session, and
+	// marks its end when sent by the server. An empty one from the server
+	// refuses the test.
+	FrameTypeSpeedTest uint8 = 0x06
+	// FrameTypeSpeedTestData carries the filler streamed during a speed
+	// test. It is kept apart from PADDING_CTRL so that receivers never take
+	// test payload for padding instructions.
+	FrameTypeSpeedTestData uint8 = 0x07
 
 	FrameHeaderSize = 3 // 2 bytes length + 1 byte type
 	MaxFramePayload = 16384
@@ -37,6 +49,10 @@ type Session struct {
 	writeNonce uint64
 	readMu     sync.Mutex
 	writeMu    sync.Mutex
+	// Nonces advance per direction, so reads and writes only need to stay
+	// ordered among themselves and get a lane each.
+	readLane  *cryptoLane
+	writeLane *cryptoLane
 }
 
 // NewSession creates a new encrypted session using ChaCha20-Poly1305.
@@ -56,6 +72,46 @@ func NewSession(sessionKey []byte) (*Session, error) {
 	}, nil
 }
 
+// SetCryptoPool offloads this session's AEAD open/seal to the given pool.
+// Frames of the session are still processed in order. It must be called
+// before any frame is read or written.
+func (s *Session) SetCryptoPool(pool *CryptoPool) {
+	if pool == nil {
+		s.readLane, s.writeLane = nil, nil
+		return
+	}
+	s.readLane, s.writeLane = pool.newLane(), pool.newLane()
+}
+
+func (s *Session) open(nonce, ciphertext []byte) ([]byte, error) {
+	if s.readLane == nil {
+		return s.aead.Open(nil, nonce, ciphertext, nil)
+	}
+	var (
+		plaintext []byte
+		err       error
+	)
+	if poolErr := s.readLane.do(func() {
+		plaintext, err = s.aead.Open(nil, nonce, ciphertext, nil)
+	}); poolErr != nil {
+		return nil, poolErr
+	}
+	return plaintext, err
+}
+
+func (s *Session) seal(nonce, plaintext []byte) ([]byte, error) {
+	if s.writeLane == nil {
+		return s.aead.Seal(nil, nonce, plaintext, nil), nil
+	}
+	var ciphertext []byte
+	if err := s.writeLane.do(func() {
+		ciphertext = s.aead.Seal(nil, nonce, plaintext, nil)
+	}); err != nil {
+		return nil, err
+	}
+	return ciphertext, nil
+}
+
 func (s *Session) nextReadNonce() []byte {
 	nonce := make([]byte, chacha20poly1305.NonceSize)
 	binary.BigEndian.PutUint64(nonce[4:], s.readNonce)
@@ -93,7 +149,7 @@ func (s *Session) ReadFrame(reader io.Reader) (*Frame, error) {
 	}
 
 	nonce := s.nextReadNonce()
-	payload, err := s.aead.Open(nil, nonce, encryptedPayload, nil)
+	payload, err := s.open(nonce, encryptedPayload)
 	if err != nil {
 		return nil, errors.New("AEAD decryption failed").Base(err)
 	}
@@ -111,7 +167,10 @@ func (s *Session) WriteFrame(writer io.Writer, frameType uint8, data []byte) err
 	defer s.writeMu.Unlock()
 
 	nonce := s.nextWriteNonce()
-	encrypted := s.aead.Seal(nil, nonce, data, nil)
+	encrypted, err := s.seal(nonce, data)
+	if err != nil {
+		return errors.New("AEAD encryption failed").Base(err)
+	}
 
 	header := make([]byte, FrameHeaderSize)
 	binary.BigEndian.PutUint16(header[0:2], uint16(len(encrypted)))
@@ -0,0 +1,74 @@
+package command
+
+import (
+	"context"
+
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/errors"
+	"github.com/xtls/xray-core/proxy/reflex"
+	grpc "google.golang.org/grpc"
+)
+
+// ProfileServer exposes the Reflex morph profile catalog.
+type ProfileServer struct{}
+
+// ListProfiles implements ReflexProfileService.
+func (s *ProfileServer) ListProfiles(ctx context.Context, request *ListProfilesRequest) (*ListProfilesResponse, error) {
+	response := &ListProfilesResponse{}
+	for _, id := range reflex.ProfileIDs() {
+		p, ok := reflex.LookupProfile(id)
+		if !ok {
+			continue
+		}
+		response.Profiles = append(response.Profiles, toProto(reflex.SpecFromProfile(id, p)))
+	}
+	return response, nil
+}
+
+// GetProfile implements ReflexProfileService.
+func (s *ProfileServer) GetProfile(ctx context.Context, request *GetProfileRequest) (*GetProfileResponse, error) {
+	data, err := reflex.MarshalProfileJSON(request.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return &GetProfileResponse{Json: string(data)}, nil
+}
+
+// PutProfile implements ReflexProfileService.
+func (s *ProfileServer) PutProfile(ctx context.Context, request *PutProfileRequest) (*PutProfileResponse, error) {
+	id, err := reflex.RegisterProfileJSON([]byte(request.GetJson()))
+	if err != nil {
+		return nil, errors.New("failed to put profile").Base(err)
+	}
+	errors.LogInfo(ctx, "reflex profile updated: ", id)
+	return &PutProfileResponse{Id: id}, nil
+}
+
+func (s *ProfileServer) mustEmbedUnimplementedReflexProfileServiceServer() {}
+
+func toProto(spec *reflex.ProfileSpec) *Profile {
+	p := &Profile{
+		Id:      spec.ID,
+		Name:    spec.Name,
+		Builtin: spec.Builtin,
+	}
+	for _, d := range spec.PacketSizes {
+		p.PacketSizes = append(p.PacketSizes, &PacketSize{Size: uint32(d.Size), Weight: d.Weight})
+	}
+	for _, d := range spec.Delays {
+		p.Delays = append(p.Delays, &Delay{DelayMs: d.DelayMs, Weight: d.Weight})
+	}
+	return p
+}
+
+type service struct{}
+
+func (s *service) Register(server *grpc.Server) {
+	RegisterReflexProfileServiceServer(server, &ProfileServer{})
+}
+
+func init() {
+	common.Must(common.RegisterConfig((*Config)(nil), func(ctx context.Context, cfg interface{}) (interface{}, error) {
+		return &service{}, nil
+	}))
+}
@@ -0,0 +1,86 @@
+package command_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/proxy/reflex"
+	. "github.com/xtls/xray-core/proxy/reflex/command"
+)
+
+const customProfile = `{
+  "id": "command-test",
+  "name": "Command Test",
+  "packetSizes": [{"size": 400, "weight": 0.5}, {"size": 900, "weight": 0.5}],
+  "delays": [{"delayMs": 20, "weight": 1}]
+}`
+
+func TestListProfiles(t *testing.T) {
+	server := &ProfileServer{}
+	resp, err := server.ListProfiles(context.Background(), &ListProfilesRequest{})
+	common.Must(err)
+
+	var youtube *Profile
+	for _, p := range resp.Profiles {
+		if p.Id == "youtube" {
+			youtube = p
+		}
+	}
+	if youtube == nil {
+		t.Fatal("builtin youtube profile not listed")
+	}
+	if !youtube.Builtin {
+		t.Fatal("youtube should be reported as builtin")
+	}
+	if len(youtube.PacketSizes) == 0 || len(youtube.Delays) == 0 {
+		t.Fatal("expected distributions in listed profile")
+	}
+}
+
+func TestPutAndGetProfile(t *testing.T) {
+	server := &ProfileServer{}
+	put, err := server.PutProfile(context.Background(), &PutProfileRequest{Json: customProfile})
+	common.Must(err)
+	t.Cleanup(func() { _ = reflex.RemoveProfile(put.Id) })
+	if put.Id != "command-test" {
+		t.Fatalf("unexpected id %q", put.Id)
+	}
+
+	got, err := server.GetProfile(context.Background(), &GetProfileRequest{Id: "command-test"})
+	common.Must(err)
+	if !strings.Contains(got.Json, `"delayMs": 20`) {
+		t.Fatalf("unexpected profile JSON: %s", got.Json)
+	}
+
+	list, err := server.ListProfiles(context.Background(), &ListProfilesRequest{})
+	common.Must(err)
+	found := false
+	for _, p := range list.Profiles {
+		if p.Id == "command-test" {
+			found = true
+			if p.Builtin {
+				t.Fatal("custom profile reported as builtin")
+			}
+		}
+	}
+	if !found {
+		t.Fatal("custom profile not listed")
+	}
+}
+
+func TestPutProfileRejectsBuiltin(t *testing.T) {
+	server := &ProfileServer{}
+	json := strings.Replace(customProfile, "command-test", "zoom", 1)
+	if _, err := server.PutProfile(context.Background(), &PutProfileRequest{Json: json}); err == nil {
+		t.Fatal("expected error when replacing a builtin profile")
+	}
+}
+
+func TestGetUnknownProfile(t *testing.T) {
+	server := &ProfileServer{}
+	if _, err := server.GetProfile(context.Background(), &GetProfileRequest{Id: "does-not-exist"}); err == nil {
+		t.Fatal("expected error for unknown profile")
+	}
+}
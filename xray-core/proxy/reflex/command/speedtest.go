@@ -0,0 +1,78 @@
+package command
+
+import (
+	"context"
+
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/errors"
+	"github.com/xtls/xray-core/common/session"
+	"github.com/xtls/xray-core/core"
+	"github.com/xtls/xray-core/features/outbound"
+	"github.com/xtls/xray-core/proxy"
+	reflexoutbound "github.com/xtls/xray-core/proxy/reflex/outbound"
+	"github.com/xtls/xray-core/transport/internet"
+	grpc "google.golang.org/grpc"
+)
+
+// SpeedTestServer runs in-band speed tests through Reflex outbounds.
+type SpeedTestServer struct {
+	OutboundManager outbound.Manager
+}
+
+// SpeedTest implements ReflexSpeedTestService.
+func (s *SpeedTestServer) SpeedTest(ctx context.Context, request *SpeedTestRequest) (*SpeedTestResponse, error) {
+	handler := s.OutboundManager.GetHandler(request.GetOutboundTag())
+	if handler == nil {
+		return nil, errors.New("outbound not found: ", request.GetOutboundTag())
+	}
+	gi, ok := handler.(proxy.GetOutbound)
+	if !ok {
+		return nil, errors.New("can't get outbound proxy from handler")
+	}
+	reflexHandler, ok := gi.GetOutbound().(*reflexoutbound.Handler)
+	if !ok {
+		return nil, errors.New("outbound ", request.GetOutboundTag(), " is not a Reflex outbound")
+	}
+	dialer, ok := handler.(internet.Dialer)
+	if !ok {
+		return nil, errors.New("outbound ", request.GetOutboundTag(), " can't dial")
+	}
+
+	// The proxyman dialer expects the outbound session that routing would
+	// normally set up, e.g. to apply sendThrough.
+	ctx = session.ContextWithOutbounds(ctx, []*session.Outbound{{
+		Target: reflexHandler.ServerDestination(),
+		Tag:    request.GetOutboundTag(),
+	}})
+	result, err := reflexHandler.SpeedTest(ctx, dialer, request.GetSize())
+	if err != nil {
+		return nil, errors.New("speed test failed").Base(err)
+	}
+	return &SpeedTestResponse{
+		PayloadBytes: result.PayloadBytes,
+		WireBytes:    result.WireBytes,
+		DurationMs:   result.Duration.Milliseconds(),
+		Mbps:         result.Mbps(),
+	}, nil
+}
+
+func (s *SpeedTestServer) mustEmbedUnimplementedReflexSpeedTestServiceServer() {}
+
+type speedTestService struct {
+	v *core.Instance
+}
+
+func (s *speedTestService) Register(server *grpc.Server) {
+	st := &SpeedTestServer{}
+	common.Must(s.v.RequireFeatures(func(om outbound.Manager) {
+		st.OutboundManager = om
+	}, false))
+	RegisterReflexSpeedTestServiceServer(server, st)
+}
+
+func init() {
+	common.Must(common.RegisterConfig((*SpeedTestConfig)(nil), func(ctx context.Context, cfg interface{}) (interface{}, error) {
+		s := core.MustFromContext(ctx)
+		return &speedTestService{v: s}, nil
+	}))
+}
@@ -0,0 +1,84 @@
+package command_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/xtls/xray-core/app/policy"
+	"github.com/xtls/xray-core/app/proxyman"
+	_ "github.com/xtls/xray-core/app/proxyman/outbound"
+	"github.com/xtls/xray-core/common"
+	xnet "github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/common/serial"
+	"github.com/xtls/xray-core/core"
+	"github.com/xtls/xray-core/features/outbound"
+	"github.com/xtls/xray-core/proxy/reflex"
+	. "github.com/xtls/xray-core/proxy/reflex/command"
+	"github.com/xtls/xray-core/proxy/reflex/internal/reflextest"
+	_ "github.com/xtls/xray-core/transport/internet/tcp"
+)
+
+// serveSpeedTest accepts one Reflex session on listener and answers its
+// speed test request.
+func serveSpeedTest(listener net.Listener) error {
+	conn, err := listener.Accept()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+
+	sess, request, err := reflextest.Accept(conn)
+	if err != nil {
+		return err
+	}
+	size, _ := reflex.DecodeSpeedTest(request)
+	return reflex.ServeSpeedTest(sess, conn, nil, size)
+}
+
+func TestSpeedTestWithSendThrough(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	common.Must(err)
+	defer func() { _ = listener.Close() }()
+
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- serveSpeedTest(listener)
+	}()
+
+	config := &core.Config{
+		App: []*serial.TypedMessage{
+			serial.ToTypedMessage(&policy.Config{}),
+			serial.ToTypedMessage(&proxyman.OutboundConfig{}),
+		},
+		Outbound: []*core.OutboundHandlerConfig{{
+			Tag: "reflex-out",
+			SenderSettings: serial.ToTypedMessage(&proxyman.SenderConfig{
+				Via: xnet.NewIPOrDomain(xnet.LocalHostIP),
+			}),
+			ProxySettings: serial.ToTypedMessage(&reflex.OutboundConfig{
+				Address: "127.0.0.1",
+				Port:    uint32(listener.Addr().(*net.TCPAddr).Port),
+				Id:      "b831381d-6324-4d53-ad4f-8cda48b30811",
+			}),
+		}},
+	}
+	v, err := core.New(config)
+	common.Must(err)
+	defer func() { _ = v.Close() }()
+
+	server := &SpeedTestServer{
+		OutboundManager: v.GetFeature(outbound.ManagerType()).(outbound.Manager),
+	}
+	const size = 64 << 10
+	resp, err := server.SpeedTest(context.Background(), &SpeedTestRequest{
+		OutboundTag: "reflex-out",
+		Size:        size,
+	})
+	common.Must(err)
+	common.Must(<-serverErr)
+
+	if resp.PayloadBytes != size {
+		t.Fatalf("expected %d payload bytes, got %d", size, resp.PayloadBytes)
+	}
+}
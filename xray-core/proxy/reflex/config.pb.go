@@ -118,12 +118,15 @@ func (x *Account) GetId() string {
 }
 
 type InboundConfig struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Clients       []*User                `protobuf:"bytes,1,rep,name=clients,proto3" json:"clients,omitempty"`
-	Fallback      *Fallback              `protobuf:"bytes,2,opt,name=fallback,proto3" json:"fallback,omitempty"`
-	Ech           *ECHSettings           `protobuf:"bytes,3,opt,name=ech,proto3" json:"ech,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Clients           []*User                `protobuf:"bytes,1,rep,name=clients,proto3" json:"clients,omitempty"`
+	Fallback          *Fallback              `protobuf:"bytes,2,opt,name=fallback,proto3" json:"fallback,omitempty"`
+	Ech               *ECHSettings           `protobuf:"bytes,3,opt,name=ech,proto3" json:"ech,omitempty"`
+	CryptoWorkers     uint32                 `protobuf:"varint,4,opt,name=crypto_workers,json=cryptoWorkers,proto3" json:"crypto_workers,omitempty"`
+	MaxTimestampDrift uint32                 `protobuf:"varint,5,opt,name=max_timestamp_drift,json=maxTimestampDrift,proto3" json:"max_timestamp_drift,omitempty"`
+	SpeedTest         *SpeedTestSettings     `protobuf:"bytes,6,opt,name=speed_test,json=speedTest,proto3" json:"speed_test,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *InboundConfig) Reset() {
@@ -177,6 +180,27 @@ func (x *InboundConfig) GetEch() *ECHSettings {
 	return nil
 }
 
+func (x *InboundConfig) GetCryptoWorkers() uint32 {
+	if x != nil {
+		return x.CryptoWorkers
+	}
+	return 0
+}
+
+func (x *InboundConfig) GetMaxTimestampDrift() uint32 {
+	if x != nil {
+		return x.MaxTimestampDrift
+	}
+	return 0
+}
+
+func (x *InboundConfig) GetSpeedTest() *SpeedTestSettings {
+	if x != nil {
+		return x.SpeedTest
+	}
+	return nil
+}
+
 type Fallback struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Dest          uint32                 `protobuf:"varint,1,opt,name=dest,proto3" json:"dest,omitempty"`
@@ -221,6 +245,69 @@ func (x *Fallback) GetDest() uint32 {
 	return 0
 }
 
+type SpeedTestSettings struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Enabled bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// Largest test a client may request, in bytes; 0 keeps MaxSpeedTestBytes.
+	MaxBytes uint64 `protobuf:"varint,2,opt,name=max_bytes,json=maxBytes,proto3" json:"max_bytes,omitempty"`
+	// Minimum time between two tests by the same user, in seconds; 0 keeps
+	// DefaultSpeedTestInterval.
+	Interval      uint32 `protobuf:"varint,3,opt,name=interval,proto3" json:"interval,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SpeedTestSettings) Reset() {
+	*x = SpeedTestSettings{}
+	mi := &file_proxy_reflex_config_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SpeedTestSettings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpeedTestSettings) ProtoMessage() {}
+
+func (x *SpeedTestSettings) ProtoReflect() protoreflect.Message {
+	mi := &file_proxy_reflex_config_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpeedTestSettings.ProtoReflect.Descriptor instead.
+func (*SpeedTestSettings) Descriptor() ([]byte, []int) {
+	return file_proxy_reflex_config_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SpeedTestSettings) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *SpeedTestSettings) GetMaxBytes() uint64 {
+	if x != nil {
+		return x.MaxBytes
+	}
+	return 0
+}
+
+func (x *SpeedTestSettings) GetInterval() uint32 {
+	if x != nil {
+		return x.Interval
+	}
+	return 0
+}
+
 type OutboundConfig struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Address       string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
@@ -228,13 +315,14 @@ type OutboundConfig struct {
 	Id            string                 `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`
 	Policy        string                 `protobuf:"bytes,4,opt,name=policy,proto3" json:"policy,omitempty"`
 	Ech           *ECHSettings           `protobuf:"bytes,5,opt,name=ech,proto3" json:"ech,omitempty"`
+	RangeHints    bool                   `protobuf:"varint,6,opt,name=range_hints,json=rangeHints,proto3" json:"range_hints,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *OutboundConfig) Reset() {
 	*x = OutboundConfig{}
-	mi := &file_proxy_reflex_config_proto_msgTypes[4]
+	mi := &file_proxy_reflex_config_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -246,7 +334,7 @@ func (x *OutboundConfig) String() string {
 func (*OutboundConfig) ProtoMessage() {}
 
 func (x *OutboundConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_proxy_reflex_config_proto_msgTypes[4]
+	mi := &file_proxy_reflex_config_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -259,7 +347,7 @@ func (x *OutboundConfig) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OutboundConfig.ProtoReflect.Descriptor instead.
 func (*OutboundConfig) Descriptor() ([]byte, []int) {
-	return file_proxy_reflex_config_proto_rawDescGZIP(), []int{4}
+	return file_proxy_reflex_config_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *OutboundConfig) GetAddress() string {
@@ -297,6 +385,13 @@ func (x *OutboundConfig) GetEch() *ECHSettings {
 	return nil
 }
 
+func (x *OutboundConfig) GetRangeHints() bool {
+	if x != nil {
+		return x.RangeHints
+	}
+	return false
+}
+
 type ECHSettings struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Enabled       bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
@@ -311,7 +406,7 @@ type ECHSettings struct {
 
 func (x *ECHSettings) Reset() {
 	*x = ECHSettings{}
-	mi := &file_proxy_reflex_config_proto_msgTypes[5]
+	mi := &file_proxy_reflex_config_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -323,7 +418,7 @@ func (x *ECHSettings) String() string {
 func (*ECHSettings) ProtoMessage() {}
 
 func (x *ECHSettings) ProtoReflect() protoreflect.Message {
-	mi := &file_proxy_reflex_config_proto_msgTypes[5]
+	mi := &file_proxy_reflex_config_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -336,7 +431,7 @@ func (x *ECHSettings) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ECHSettings.ProtoReflect.Descriptor instead.
 func (*ECHSettings) Descriptor() ([]byte, []int) {
-	return file_proxy_reflex_config_proto_rawDescGZIP(), []int{5}
+	return file_proxy_reflex_config_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ECHSettings) GetEnabled() bool {
@@ -390,19 +485,29 @@ const file_proxy_reflex_config_proto_rawDesc = "" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
 	"\x06policy\x18\x02 \x01(\tR\x06policy\"\x19\n" +
 	"\aAccount\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"\x9e\x01\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xb5\x02\n" +
 	"\rInboundConfig\x12,\n" +
 	"\aclients\x18\x01 \x03(\v2\x12.reflex.proxy.UserR\aclients\x122\n" +
 	"\bfallback\x18\x02 \x01(\v2\x16.reflex.proxy.FallbackR\bfallback\x12+\n" +
-	"\x03ech\x18\x03 \x01(\v2\x19.reflex.proxy.ECHSettingsR\x03ech\"\x1e\n" +
+	"\x03ech\x18\x03 \x01(\v2\x19.reflex.proxy.ECHSettingsR\x03ech\x12%\n" +
+	"\x0ecrypto_workers\x18\x04 \x01(\rR\rcryptoWorkers\x12.\n" +
+	"\x13max_timestamp_drift\x18\x05 \x01(\rR\x11maxTimestampDrift\x12>\n" +
+	"\n" +
+	"speed_test\x18\x06 \x01(\v2\x1f.reflex.proxy.SpeedTestSettingsR\tspeedTest\"\x1e\n" +
 	"\bFallback\x12\x12\n" +
-	"\x04dest\x18\x01 \x01(\rR\x04dest\"\x93\x01\n" +
+	"\x04dest\x18\x01 \x01(\rR\x04dest\"f\n" +
+	"\x11SpeedTestSettings\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12\x1b\n" +
+	"\tmax_bytes\x18\x02 \x01(\x04R\bmaxBytes\x12\x1a\n" +
+	"\binterval\x18\x03 \x01(\rR\binterval\"\xb4\x01\n" +
 	"\x0eOutboundConfig\x12\x18\n" +
 	"\aaddress\x18\x01 \x01(\tR\aaddress\x12\x12\n" +
 	"\x04port\x18\x02 \x01(\rR\x04port\x12\x0e\n" +
 	"\x02id\x18\x03 \x01(\tR\x02id\x12\x16\n" +
 	"\x06policy\x18\x04 \x01(\tR\x06policy\x12+\n" +
-	"\x03ech\x18\x05 \x01(\v2\x19.reflex.proxy.ECHSettingsR\x03ech\"\xbd\x01\n" +
+	"\x03ech\x18\x05 \x01(\v2\x19.reflex.proxy.ECHSettingsR\x03ech\x12\x1f\n" +
+	"\vrange_hints\x18\x06 \x01(\bR\n" +
+	"rangeHints\"\xbd\x01\n" +
 	"\vECHSettings\x12\x18\n" +
 	"\aenabled\x18\x01 \x01(\bR\aenabled\x12\x1f\n" +
 	"\vpublic_name\x18\x02 \x01(\tR\n" +
@@ -425,25 +530,27 @@ func file_proxy_reflex_config_proto_rawDescGZIP() []byte {
 	return file_proxy_reflex_config_proto_rawDescData
 }
 
-var file_proxy_reflex_config_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_proxy_reflex_config_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_proxy_reflex_config_proto_goTypes = []any{
-	(*User)(nil),           // 0: reflex.proxy.User
-	(*Account)(nil),        // 1: reflex.proxy.Account
-	(*InboundConfig)(nil),  // 2: reflex.proxy.InboundConfig
-	(*Fallback)(nil),       // 3: reflex.proxy.Fallback
-	(*OutboundConfig)(nil), // 4: reflex.proxy.OutboundConfig
-	(*ECHSettings)(nil),    // 5: reflex.proxy.ECHSettings
+	(*User)(nil),              // 0: reflex.proxy.User
+	(*Account)(nil),           // 1: reflex.proxy.Account
+	(*InboundConfig)(nil),     // 2: reflex.proxy.InboundConfig
+	(*Fallback)(nil),          // 3: reflex.proxy.Fallback
+	(*SpeedTestSettings)(nil), // 4: reflex.proxy.SpeedTestSettings
+	(*OutboundConfig)(nil),    // 5: reflex.proxy.OutboundConfig
+	(*ECHSettings)(nil),       // 6: reflex.proxy.ECHSettings
 }
 var file_proxy_reflex_config_proto_depIdxs = []int32{
 	0, // 0: reflex.proxy.InboundConfig.clients:type_name -> reflex.proxy.User
 	3, // 1: reflex.proxy.InboundConfig.fallback:type_name -> reflex.proxy.Fallback
-	5, // 2: reflex.proxy.InboundConfig.ech:type_name -> reflex.proxy.ECHSettings
-	5, // 3: reflex.proxy.OutboundConfig.ech:type_name -> reflex.proxy.ECHSettings
-	4, // [4:4] is the sub-list for method output_type
-	4, // [4:4] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	6, // 2: reflex.proxy.InboundConfig.ech:type_name -> reflex.proxy.ECHSettings
+	4, // 3: reflex.proxy.InboundConfig.speed_test:type_name -> reflex.proxy.SpeedTestSettings
+	6, // 4: reflex.proxy.OutboundConfig.ech:type_name -> reflex.proxy.ECHSettings
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_proxy_reflex_config_proto_init() }
@@ -457,7 +564,7 @@ func file_proxy_reflex_config_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proxy_reflex_config_proto_rawDesc), len(file_proxy_reflex_config_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -19,6 +19,10 @@ const (
 	ReflexMagic            uint32 = 0x5246584C // "RFXL"
 	HandshakeHeaderSize           = 4 + 32 + 16 + 8 + 16 // magic + pubkey + uuid + timestamp + nonce
 	MaxTimestampDrift             = 120 // seconds
+	// MaxTimestampDriftLimit bounds a configured drift window. Replays are
+	// only caught while their nonce is still tracked, and the tracker holds a
+	// fixed number of nonces, so a wider window weakens replay protection.
+	MaxTimestampDriftLimit = 600 // seconds
 )
 
 // ClientHandshake contains the client-side handshake data.
@@ -128,12 +132,18 @@ func UnmarshalServerHandshake(data []byte) (*ServerHandshake, error) {
 
 // ValidateTimestamp checks that the handshake timestamp is within acceptable drift.
 func ValidateTimestamp(timestamp int64) bool {
+	return ValidateTimestampWithin(timestamp, MaxTimestampDrift)
+}
+
+// ValidateTimestampWithin checks the handshake timestamp against a custom
+// drift window in seconds.
+func ValidateTimestampWithin(timestamp int64, maxDrift int64) bool {
 	now := time.Now().Unix()
 	diff := now - timestamp
 	if diff < 0 {
 		diff = -diff
 	}
-	return diff <= MaxTimestampDrift
+	return diff <= maxDrift
 }
 
 // AuthenticateUser looks up a user by UUID from the client list.
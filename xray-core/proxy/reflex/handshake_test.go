@@ -338,3 +338,13 @@ func BenchmarkDeriveSessionKey(b *testing.B) {
 		_, _ = DeriveSessionKey(secret, nonce)
 	}
 }
+
+func TestValidateTimestampWithin(t *testing.T) {
+	old := time.Now().Unix() - 300
+	if ValidateTimestampWithin(old, MaxTimestampDrift) {
+		t.Fatal("timestamp outside the default window should be rejected")
+	}
+	if !ValidateTimestampWithin(old, 600) {
+		t.Fatal("timestamp inside the widened window should be accepted")
+	}
+}
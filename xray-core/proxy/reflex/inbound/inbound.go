@@ -6,6 +6,7 @@ import (
 	"crypto/tls"
 	"encoding/binary"
 	"io"
+	"sync/atomic"
 	"time"
 
 	"github.com/xtls/xray-core/common"
@@ -20,6 +21,7 @@ import (
 	"github.com/xtls/xray-core/core"
 	"github.com/xtls/xray-core/features/policy"
 	"github.com/xtls/xray-core/features/routing"
+	"github.com/xtls/xray-core/features/stats"
 	"github.com/xtls/xray-core/proxy/reflex"
 	"github.com/xtls/xray-core/transport/internet"
 	"github.com/xtls/xray-core/transport/internet/stat"
@@ -39,6 +41,10 @@ type Handler struct {
 	fallback      *reflex.Fallback
 	nonceTracker  *reflex.NonceTracker
 	tlsConfig     *tls.Config
+	cryptoPool    *reflex.CryptoPool
+	maxDrift      int64
+	speedTest     *reflex.SpeedTestLimiter
+	speedTestMax  uint64
 }
 
 // New creates a new Reflex inbound handler.
@@ -49,6 +55,7 @@ func New(ctx context.Context, config *reflex.InboundConfig) (*Handler, error) {
 		clients:       make([]*protocol.MemoryUser, 0, len(config.GetClients())),
 		clientEntries: make([]*reflex.ClientEntry, 0, len(config.GetClients())),
 		nonceTracker:  reflex.NewNonceTracker(10000),
+		maxDrift:      reflex.MaxTimestampDrift,
 	}
 
 	for _, client := range config.GetClients() {
@@ -79,6 +86,37 @@ func New(ctx context.Context, config *reflex.InboundConfig) (*Handler, error) {
 		handler.tlsConfig = tlsCfg
 	}
 
+	if workers := config.GetCryptoWorkers(); workers > 0 {
+		handler.cryptoPool = reflex.SharedCryptoPool(int(workers))
+		if n := handler.cryptoPool.Workers(); n != int(workers) {
+			errors.LogWarning(ctx, "reflex: cryptoWorkers is ", workers, " but the process-wide crypto pool already runs ", n, " workers; the pool is shared by all inbounds and never shrinks")
+		}
+	}
+
+	if st := config.GetSpeedTest(); st.GetEnabled() {
+		interval := reflex.DefaultSpeedTestInterval
+		if st.GetInterval() > 0 {
+			interval = time.Duration(st.GetInterval()) * time.Second
+		}
+		handler.speedTest = reflex.NewSpeedTestLimiter(interval)
+		handler.speedTestMax = st.GetMaxBytes()
+	}
+
+	if drift := config.GetMaxTimestampDrift(); drift > 0 {
+		handler.maxDrift = int64(drift)
+	}
+
+	// The tag and streamSettings belong to the proxyman handler, which is
+	// only known when the inbound is created through it.
+	inboundHandler := session.InboundHandlerFromContext(ctx)
+	report := reflex.AuditInbound(config, inboundHandler)
+	report.Log(ctx)
+	if inboundHandler != nil && inboundHandler.Tag != "" {
+		if c, _ := stats.GetOrRegisterCounter(v.GetFeature(stats.ManagerType()).(stats.Manager), reflex.StealthScoreCounter(inboundHandler.Tag)); c != nil {
+			c.Set(int64(report.Score))
+		}
+	}
+
 	return handler, nil
 }
 
@@ -150,7 +188,7 @@ func (h *Handler) Process(ctx context.Context, network net.Network, conn stat.Co
 		return errors.New("invalid handshake").Base(err).AtWarning()
 	}
 
-	if !reflex.ValidateTimestamp(clientHS.Timestamp) {
+	if !reflex.ValidateTimestampWithin(clientHS.Timestamp, h.maxDrift) {
 		return errors.New("handshake timestamp out of range").AtWarning()
 	}
 
@@ -199,6 +237,9 @@ func (h *Handler) handleSession(ctx context.Context, reader *bufio.Reader, conn
 	if err != nil {
 		return errors.New("failed to create session").Base(err).AtError()
 	}
+	if h.cryptoPool != nil {
+		sess.SetCryptoPool(h.cryptoPool)
+	}
 
 	morph := reflex.NewTrafficMorph(client.Policy)
 
@@ -215,6 +256,9 @@ func (h *Handler) handleSession(ctx context.Context, reader *bufio.Reader, conn
 	if err != nil {
 		return errors.New("failed to read first frame").Base(err).AtWarning()
 	}
+	if firstFrame.Type == reflex.FrameTypeSpeedTest {
+		return h.handleSpeedTest(ctx, sess, conn, morph, firstFrame, client)
+	}
 	if firstFrame.Type != reflex.FrameTypeData || len(firstFrame.Payload) == 0 {
 		return errors.New("expected DATA frame with destination").AtWarning()
 	}
@@ -224,8 +268,22 @@ func (h *Handler) handleSession(ctx context.Context, reader *bufio.Reader, conn
 		return errors.New("failed to parse destination").Base(err).AtWarning()
 	}
 
+	var (
+		idle       atomic.Bool
+		halfClosed atomic.Bool
+		rangeHints atomic.Bool
+		forwarded  atomic.Uint64
+		speedTest  atomic.Bool
+	)
 	ctx, cancel := context.WithCancel(ctx)
-	timer := signal.CancelAfterInactivity(ctx, cancel, sessionPolicy.Timeouts.ConnectionIdle)
+	timer := signal.CancelAfterInactivity(ctx, func() {
+		// Once one direction has finished, the timer only runs the
+		// UplinkOnly/DownlinkOnly grace period, which is not an idle session.
+		if !halfClosed.Load() {
+			idle.Store(true)
+		}
+		cancel()
+	}, sessionPolicy.Timeouts.ConnectionIdle)
 
 	link, err := dispatcher.Dispatch(ctx, dest)
 	if err != nil {
@@ -233,15 +291,20 @@ func (h *Handler) handleSession(ctx context.Context, reader *bufio.Reader, conn
 	}
 
 	requestDone := func() error {
-		defer timer.SetTimeout(sessionPolicy.Timeouts.DownlinkOnly)
+		defer func() {
+			halfClosed.Store(true)
+			timer.SetTimeout(sessionPolicy.Timeouts.DownlinkOnly)
+		}()
 
 		if len(payload) > 0 {
 			mb := buf.MultiBuffer{buf.FromBytes(payload)}
 			if err := link.Writer.WriteMultiBuffer(mb); err != nil {
 				return errors.New("failed to write first payload").Base(err).AtWarning()
 			}
+			forwarded.Add(uint64(len(payload)))
 		}
 
+		var hinted uint64
 		for {
 			frame, err := sess.ReadFrame(reader)
 			if err != nil {
@@ -253,12 +316,28 @@ func (h *Handler) handleSession(ctx context.Context, reader *bufio.Reader, conn
 				if err := link.Writer.WriteMultiBuffer(mb); err != nil {
 					return err
 				}
+				if n := forwarded.Add(uint64(len(frame.Payload))); rangeHints.Load() && n-hinted >= reflex.RangeHintInterval {
+					if err := sess.WriteRangeHint(conn, n); err != nil {
+						return errors.New("failed to write range hint").Base(err).AtInfo()
+					}
+					hinted = n
+				}
 				timer.Update()
 			case reflex.FrameTypePadding, reflex.FrameTypeTiming:
 				if morph != nil && morph.Profile != nil {
 					reflex.HandleControlFrame(frame, morph.Profile)
 				}
 				continue
+			case reflex.FrameTypeRangeHint:
+				rangeHints.Store(true)
+				hinted = forwarded.Load()
+				if err := sess.WriteRangeHint(conn, hinted); err != nil {
+					return errors.New("failed to write range hint").Base(err).AtInfo()
+				}
+			case reflex.FrameTypeSpeedTest:
+				if err := h.startLiveSpeedTest(ctx, sess, conn, morph, frame, client, &speedTest); err != nil {
+					return errors.New("failed to refuse speed test").Base(err).AtInfo()
+				}
 			case reflex.FrameTypeClose:
 				return nil
 			default:
@@ -268,7 +347,10 @@ func (h *Handler) handleSession(ctx context.Context, reader *bufio.Reader, conn
 	}
 
 	responseDone := func() error {
-		defer timer.SetTimeout(sessionPolicy.Timeouts.UplinkOnly)
+		defer func() {
+			halfClosed.Store(true)
+			timer.SetTimeout(sessionPolicy.Timeouts.UplinkOnly)
+		}()
 
 		for {
 			mb, err := link.Reader.ReadMultiBuffer()
@@ -298,12 +380,95 @@ func (h *Handler) handleSession(ctx context.Context, reader *bufio.Reader, conn
 	if err := task.Run(ctx, requestDone, responseDoneAndCloseWriter); err != nil {
 		_ = common.Interrupt(link.Reader)
 		_ = common.Interrupt(link.Writer)
+		// Only an idle timeout leaves the connection usable for a final
+		// hint; any other failure here usually means it is already gone.
+		if idle.Load() {
+			if rangeHints.Load() {
+				_ = sess.WriteRangeHint(conn, forwarded.Load())
+			}
+			_ = sess.WriteCloseFrameWithReason(conn, reflex.CloseReasonIdleTimeout, 0)
+		}
 		return errors.New("connection ends").Base(err).AtInfo()
 	}
 
 	return nil
 }
 
+var (
+	errSpeedTestDisabled = errors.New("speed test requested but not enabled")
+	errSpeedTestLimited  = errors.New("speed test rate limited")
+)
+
+// admitSpeedTest checks a speed test request against the inbound's settings
+// and the user's rate limit, and returns the number of bytes to serve.
+func (h *Handler) admitSpeedTest(request *reflex.Frame, client *reflex.ClientEntry) (uint64, error) {
+	if h.speedTest == nil {
+		return 0, errSpeedTestDisabled
+	}
+	size, ok := reflex.DecodeSpeedTest(request)
+	if !ok {
+		return 0, errors.New("invalid speed test request")
+	}
+	if !h.speedTest.Allow(client.ID) {
+		return 0, errSpeedTestLimited
+	}
+	if h.speedTestMax > 0 && size > h.speedTestMax {
+		size = h.speedTestMax
+	}
+	return size, nil
+}
+
+// handleSpeedTest serves an in-band speed test requested by the client in
+// place of a destination. The test data is shaped by the client's morph
+// profile, so the result reflects the configuration actually in use.
+// Speed tests must be enabled on the inbound and are rate limited per user.
+func (h *Handler) handleSpeedTest(ctx context.Context, sess *reflex.Session, conn stat.Connection, morph *reflex.TrafficMorph, request *reflex.Frame, client *reflex.ClientEntry) error {
+	size, err := h.admitSpeedTest(request, client)
+	switch err {
+	case nil:
+	case errSpeedTestDisabled:
+		_ = sess.WriteCloseFrame(conn)
+		return errors.New("speed test not served").Base(err).AtInfo()
+	case errSpeedTestLimited:
+		_ = sess.WriteCloseFrameWithReason(conn, reflex.CloseReasonQuotaExceeded, 0)
+		return errors.New("speed test not served").Base(err).AtInfo()
+	default:
+		return errors.New("speed test not served").Base(err).AtWarning()
+	}
+	errors.LogInfo(ctx, "serving speed test of ", size, " bytes")
+
+	if err := reflex.ServeSpeedTest(sess, conn, morph, size); err != nil {
+		return errors.New("speed test ends").Base(err).AtInfo()
+	}
+	return nil
+}
+
+// startLiveSpeedTest serves a speed test requested in the middle of a session,
+// next to the session's regular traffic. A request that cannot run is refused
+// with an empty SPEEDTEST frame and the session carries on; running marks the
+// one test a session may have in flight.
+func (h *Handler) startLiveSpeedTest(ctx context.Context, sess *reflex.Session, conn stat.Connection, morph *reflex.TrafficMorph, request *reflex.Frame, client *reflex.ClientEntry, running *atomic.Bool) error {
+	if !running.CompareAndSwap(false, true) {
+		errors.LogInfo(ctx, "refusing speed test: one is already running on this session")
+		return sess.RefuseSpeedTest(conn)
+	}
+	size, err := h.admitSpeedTest(request, client)
+	if err != nil {
+		running.Store(false)
+		errors.LogInfoInner(ctx, err, "refusing speed test")
+		return sess.RefuseSpeedTest(conn)
+	}
+	errors.LogInfo(ctx, "serving speed test of ", size, " bytes on a live session")
+
+	go func() {
+		defer running.Store(false)
+		if err := reflex.ServeSpeedTest(sess, conn, morph, size); err != nil {
+			errors.LogInfoInner(ctx, err, "speed test ends")
+		}
+	}()
+	return nil
+}
+
 // parseDestination extracts the target address from the first DATA frame payload.
 // Format: [addrType(1)] [addr(variable)] [port(2)] [remaining payload...]
 // addrType: 1=IPv4(4 bytes), 2=domain(1 byte len + domain), 3=IPv6(16 bytes)
@@ -8,7 +8,16 @@ import (
 	"net"
 	"testing"
 
+	"github.com/xtls/xray-core/app/policy"
+	"github.com/xtls/xray-core/app/proxyman"
+	_ "github.com/xtls/xray-core/app/proxyman/inbound"
+	"github.com/xtls/xray-core/app/stats"
+	"github.com/xtls/xray-core/common"
 	xnet "github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/common/serial"
+	"github.com/xtls/xray-core/core"
+	featstats "github.com/xtls/xray-core/features/stats"
+	"github.com/xtls/xray-core/proxy/reflex"
 )
 
 func TestParseDestinationIPv4(t *testing.T) {
@@ -181,3 +190,38 @@ func TestPreloadedConnRead(t *testing.T) {
 		t.Fatalf("expected full data, got %q", buf[:n])
 	}
 }
+
+func TestStealthScoreKeyedByHandlerTag(t *testing.T) {
+	// Built from protobuf, as the HandlerService does: the tag and
+	// streamSettings reach the audit through proxyman, not the Reflex config.
+	v, err := core.New(&core.Config{
+		App: []*serial.TypedMessage{
+			serial.ToTypedMessage(&policy.Config{}),
+			serial.ToTypedMessage(&stats.Config{}),
+			serial.ToTypedMessage(&proxyman.InboundConfig{}),
+		},
+		Inbound: []*core.InboundHandlerConfig{{
+			Tag: "reflex-in",
+			ReceiverSettings: serial.ToTypedMessage(&proxyman.ReceiverConfig{
+				PortList: &xnet.PortList{Range: []*xnet.PortRange{xnet.SinglePortRange(0)}},
+				Listen:   xnet.NewIPOrDomain(xnet.LocalHostIP),
+			}),
+			ProxySettings: serial.ToTypedMessage(&reflex.InboundConfig{
+				Clients:  []*reflex.User{{Id: "b831381d-6324-4d53-ad4f-8cda48b30811", Policy: "youtube"}},
+				Fallback: &reflex.Fallback{Dest: 80},
+			}),
+		}},
+	})
+	common.Must(err)
+	defer v.Close()
+
+	manager := v.GetFeature(featstats.ManagerType()).(featstats.Manager)
+	counter := manager.GetCounter(reflex.StealthScoreCounter("reflex-in"))
+	if counter == nil {
+		t.Fatal("stealth score not registered under the inbound tag")
+	}
+	// Neither ECH nor streamSettings security protect the handshake.
+	if counter.Value() != 75 {
+		t.Fatalf("expected stealth score 75, got %d", counter.Value())
+	}
+}
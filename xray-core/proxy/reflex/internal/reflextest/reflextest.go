@@ -0,0 +1,47 @@
+// Package reflextest holds fixtures shared by the Reflex tests.
+package reflextest
+
+import (
+	"io"
+	"net"
+
+	"github.com/xtls/xray-core/proxy/reflex"
+)
+
+// Accept plays the server side of the Reflex handshake on conn and returns
+// the session together with the first frame sent by the client. It accepts
+// any user, so tests can drive a client without configuring an inbound.
+func Accept(conn net.Conn) (*reflex.Session, *reflex.Frame, error) {
+	hsData := make([]byte, reflex.HandshakeHeaderSize)
+	if _, err := io.ReadFull(conn, hsData); err != nil {
+		return nil, nil, err
+	}
+	clientHS, err := reflex.UnmarshalClientHandshake(hsData)
+	if err != nil {
+		return nil, nil, err
+	}
+	privKey, pubKey, err := reflex.GenerateKeyPair()
+	if err != nil {
+		return nil, nil, err
+	}
+	shared, err := reflex.DeriveSharedSecret(privKey, clientHS.PublicKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	key, err := reflex.DeriveSessionKey(shared, clientHS.Nonce[:])
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err := conn.Write(reflex.MarshalServerHandshake(&reflex.ServerHandshake{PublicKey: pubKey})); err != nil {
+		return nil, nil, err
+	}
+	sess, err := reflex.NewSession(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	first, err := sess.ReadFrame(conn)
+	if err != nil {
+		return nil, nil, err
+	}
+	return sess, first, nil
+}
@@ -231,13 +231,13 @@ type TrafficMorph struct {
 	Enabled bool
 }
 
-// NewTrafficMorph creates a morph engine for the named profile.
-// Returns nil if the profile name is empty or unknown.
+// NewTrafficMorph creates a morph engine for the named builtin or custom
+// profile. Returns nil if the profile name is empty or unknown.
 func NewTrafficMorph(profileName string) *TrafficMorph {
 	if profileName == "" {
 		return nil
 	}
-	p, ok := BuiltinProfiles[profileName]
+	p, ok := LookupProfile(profileName)
 	if !ok {
 		return nil
 	}
@@ -249,8 +249,13 @@ func NewTrafficMorph(profileName string) *TrafficMorph {
 
 // MorphWrite splits or pads data into profile-sized frames, applying delays.
 func (m *TrafficMorph) MorphWrite(sess *Session, writer io.Writer, data []byte) error {
+	return m.MorphWriteFrame(sess, writer, FrameTypeData, data)
+}
+
+// MorphWriteFrame is MorphWrite for an arbitrary frame type.
+func (m *TrafficMorph) MorphWriteFrame(sess *Session, writer io.Writer, frameType uint8, data []byte) error {
 	if !m.Enabled || m.Profile == nil {
-		return sess.WriteFrame(writer, FrameTypeData, data)
+		return sess.WriteFrame(writer, frameType, data)
 	}
 
 	for len(data) > 0 {
@@ -276,7 +281,7 @@ func (m *TrafficMorph) MorphWrite(sess *Session, writer io.Writer, data []byte)
 			data = data[chunkSize:]
 		}
 
-		if err := sess.WriteFrame(writer, FrameTypeData, chunk); err != nil {
+		if err := sess.WriteFrame(writer, frameType, chunk); err != nil {
 			return err
 		}
 
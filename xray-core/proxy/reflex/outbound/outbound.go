@@ -6,6 +6,8 @@ import (
 	"crypto/tls"
 	"encoding/binary"
 	"io"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/xtls/xray-core/common"
@@ -39,6 +41,19 @@ type Handler struct {
 	policyName    string
 	policyManager policy.Manager
 	tlsConfig     *tls.Config
+	rangeHints    bool
+
+	liveMu sync.Mutex
+	live   []*liveSession
+}
+
+// liveSession is a session currently carrying traffic for Process. A speed
+// test can run on it next to the regular frames; its meter is set while one
+// does.
+type liveSession struct {
+	sess  *reflex.Session
+	conn  stat.Connection
+	meter atomic.Pointer[reflex.SpeedTestMeter]
 }
 
 // New creates a new Reflex outbound handler.
@@ -50,6 +65,7 @@ func New(ctx context.Context, config *reflex.OutboundConfig) (*Handler, error) {
 		clientID:      config.GetId(),
 		policyName:    config.GetPolicy(),
 		policyManager: v.GetFeature(policy.ManagerType()).(policy.Manager),
+		rangeHints:    config.GetRangeHints(),
 	}
 
 	if ech := config.GetEch(); ech != nil && ech.GetEnabled() {
@@ -74,93 +90,16 @@ func (h *Handler) Process(ctx context.Context, link *transport.Link, dialer inte
 	ob.CanSpliceCopy = 3
 	destination := ob.Target
 
-	serverDest := net.TCPDestination(h.serverAddress, h.serverPort)
+	errors.LogInfo(ctx, "tunneling request to ", destination, " via ", net.TCPDestination(h.serverAddress, h.serverPort).NetAddr())
 
-	var conn stat.Connection
-	err := retry.ExponentialBackoff(5, 200).On(func() error {
-		rawConn, err := dialer.Dial(ctx, serverDest)
-		if err != nil {
-			return err
-		}
-		conn = rawConn
-		return nil
-	})
+	conn, sess, err := h.connect(ctx, dialer)
 	if err != nil {
-		return errors.New("failed to connect to reflex server").Base(err).AtWarning()
+		return err
 	}
 	defer func() { _ = conn.Close() }()
 
-	// If TLS+ECH is configured, wrap the outgoing TCP connection in a TLS client
-	// before proceeding with the Reflex handshake.
-	if h.tlsConfig != nil {
-		serverName := h.tlsConfig.ServerName
-		if serverName == "" {
-			serverName = h.serverAddress.String()
-		}
-		clientTLS := h.tlsConfig.Clone()
-		clientTLS.ServerName = serverName
-
-		tlsConn := tls.Client(conn, clientTLS)
-		if err := tlsConn.HandshakeContext(ctx); err != nil {
-			return errors.New("TLS+ECH client handshake failed").Base(err).AtWarning()
-		}
-		conn = stat.Connection(tlsConn)
-	}
-
-	errors.LogInfo(ctx, "tunneling request to ", destination, " via ", serverDest.NetAddr())
-
-	// --- Perform Reflex handshake ---
-	clientPrivKey, clientPubKey, err := reflex.GenerateKeyPair()
-	if err != nil {
-		return errors.New("failed to generate client keypair").Base(err).AtError()
-	}
-
-	userUUID, err := uuid.ParseString(h.clientID)
-	if err != nil {
-		return errors.New("invalid client UUID").Base(err).AtError()
-	}
-
-	var nonce [16]byte
-	if _, err := rand.Read(nonce[:]); err != nil {
-		return errors.New("failed to generate nonce").Base(err).AtError()
-	}
-
-	clientHS := &reflex.ClientHandshake{
-		PublicKey: clientPubKey,
-		UserID:    userUUID,
-		Timestamp: time.Now().Unix(),
-		Nonce:     nonce,
-	}
-
-	if _, err := conn.Write(reflex.MarshalClientHandshake(clientHS)); err != nil {
-		return errors.New("failed to send client handshake").Base(err).AtWarning()
-	}
-
-	// Read server handshake response
-	serverHSData := make([]byte, 64)
-	if _, err := io.ReadFull(conn, serverHSData); err != nil {
-		return errors.New("failed to read server handshake").Base(err).AtWarning()
-	}
-
-	serverHS, err := reflex.UnmarshalServerHandshake(serverHSData)
-	if err != nil {
-		return errors.New("invalid server handshake").Base(err).AtWarning()
-	}
-
-	// Derive session key
-	sharedSecret, err := reflex.DeriveSharedSecret(clientPrivKey, serverHS.PublicKey)
-	if err != nil {
-		return errors.New("key exchange failed").Base(err).AtError()
-	}
-	sessionKey, err := reflex.DeriveSessionKey(sharedSecret, nonce[:])
-	if err != nil {
-		return errors.New("session key derivation failed").Base(err).AtError()
-	}
-
-	sess, err := reflex.NewSession(sessionKey)
-	if err != nil {
-		return errors.New("failed to create session").Base(err).AtError()
-	}
+	live := &liveSession{sess: sess, conn: conn}
+	defer h.removeLiveSession(live)
 
 	morph := reflex.NewTrafficMorph(h.policyName)
 
@@ -171,6 +110,10 @@ func (h *Handler) Process(ctx context.Context, link *transport.Link, dialer inte
 		newCtx, newCancel = context.WithCancel(context.Background())
 	}
 
+	// reported is set once the end of the stream, with its reason or forwarded
+	// offset, has been handed to link.Writer.
+	var reported atomic.Bool
+
 	sessionPolicy := h.policyManager.ForLevel(0)
 	ctx, cancel := context.WithCancel(ctx)
 	timer := signal.CancelAfterInactivity(ctx, func() {
@@ -203,6 +146,16 @@ func (h *Handler) Process(ctx context.Context, link *transport.Link, dialer inte
 			return errors.New("failed to write first data frame").Base(err).AtWarning()
 		}
 
+		if h.rangeHints {
+			if err := sess.RequestRangeHints(conn); err != nil {
+				return errors.New("failed to request range hints").Base(err).AtWarning()
+			}
+		}
+
+		// Only now may a speed test use the session: a SPEEDTEST frame in
+		// place of the first DATA frame would replace the destination.
+		h.addLiveSession(live)
+
 		for {
 			mb, err := link.Reader.ReadMultiBuffer()
 			if err != nil {
@@ -230,9 +183,24 @@ func (h *Handler) Process(ctx context.Context, link *transport.Link, dialer inte
 	getResponse := func() error {
 		defer timer.SetTimeout(sessionPolicy.Timeouts.UplinkOnly)
 
+		var (
+			offset      uint64
+			offsetKnown bool
+		)
+
 		for {
 			frame, err := sess.ReadFrame(conn)
 			if err != nil {
+				// A clean EOF is how the server ends a complete response.
+				// Anything else broke the transfer; report how much of the
+				// upload the server had forwarded.
+				if offsetKnown && errors.Cause(err) != io.EOF {
+					interruptedErr := &reflex.InterruptedError{Offset: offset, Err: err}
+					_ = common.CloseWithError(link.Writer, interruptedErr)
+					reported.Store(true)
+					errors.LogInfo(ctx, interruptedErr.Error())
+					return nil
+				}
 				return err
 			}
 			switch frame.Type {
@@ -247,7 +215,26 @@ func (h *Handler) Process(ctx context.Context, link *transport.Link, dialer inte
 					reflex.HandleControlFrame(frame, morph.Profile)
 				}
 				continue
+			case reflex.FrameTypeRangeHint:
+				offset, offsetKnown = reflex.DecodeRangeHint(frame)
+			case reflex.FrameTypeSpeedTest, reflex.FrameTypeSpeedTestData:
+				// Frames of a speed test nobody waits for any more are
+				// dropped.
+				if meter := live.meter.Load(); meter != nil {
+					meter.Observe(frame)
+				}
 			case reflex.FrameTypeClose:
+				if closeErr := reflex.ParseCloseFrame(frame); closeErr != nil {
+					closeErr.Offset, closeErr.OffsetKnown = offset, offsetKnown
+					// Hand the typed reason to whoever reads the stream, so
+					// client applications see it instead of a bare EOF. It
+					// is not returned: a failed Process makes proxyman
+					// interrupt link.Writer, dropping unread data and the
+					// reason with it.
+					_ = common.CloseWithError(link.Writer, closeErr)
+					reported.Store(true)
+					errors.LogInfo(ctx, closeErr.Error())
+				}
 				return nil
 			default:
 				return errors.New("unknown frame type from server")
@@ -261,12 +248,202 @@ func (h *Handler) Process(ctx context.Context, link *transport.Link, dialer inte
 
 	responseDoneAndCloseWriter := task.OnSuccess(getResponse, task.Close(link.Writer))
 	if err := task.Run(ctx, postRequest, responseDoneAndCloseWriter); err != nil {
+		if reported.Load() {
+			// The session is over and its outcome is already on
+			// link.Writer; a late uplink error must not interrupt it.
+			return nil
+		}
 		return errors.New("connection ends").Base(err).AtInfo()
 	}
 
 	return nil
 }
 
+// ServerDestination returns the address of the Reflex server.
+func (h *Handler) ServerDestination() net.Destination {
+	return net.TCPDestination(h.serverAddress, h.serverPort)
+}
+
+// connect dials the Reflex server and performs the handshake, returning the
+// connection and the encrypted session established on it.
+func (h *Handler) connect(ctx context.Context, dialer internet.Dialer) (stat.Connection, *reflex.Session, error) {
+	serverDest := h.ServerDestination()
+
+	var conn stat.Connection
+	err := retry.ExponentialBackoff(5, 200).On(func() error {
+		rawConn, err := dialer.Dial(ctx, serverDest)
+		if err != nil {
+			return err
+		}
+		conn = rawConn
+		return nil
+	})
+	if err != nil {
+		return nil, nil, errors.New("failed to connect to reflex server").Base(err).AtWarning()
+	}
+
+	conn, sess, err := h.handshake(ctx, conn)
+	if err != nil {
+		_ = conn.Close()
+		return nil, nil, err
+	}
+	return conn, sess, nil
+}
+
+// handshake wraps conn in TLS+ECH when configured and runs the Reflex
+// handshake over it. The returned connection must be used for the session.
+func (h *Handler) handshake(ctx context.Context, conn stat.Connection) (stat.Connection, *reflex.Session, error) {
+	// If TLS+ECH is configured, wrap the outgoing TCP connection in a TLS client
+	// before proceeding with the Reflex handshake.
+	if h.tlsConfig != nil {
+		serverName := h.tlsConfig.ServerName
+		if serverName == "" {
+			serverName = h.serverAddress.String()
+		}
+		clientTLS := h.tlsConfig.Clone()
+		clientTLS.ServerName = serverName
+
+		tlsConn := tls.Client(conn, clientTLS)
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			return conn, nil, errors.New("TLS+ECH client handshake failed").Base(err).AtWarning()
+		}
+		conn = stat.Connection(tlsConn)
+	}
+
+	clientPrivKey, clientPubKey, err := reflex.GenerateKeyPair()
+	if err != nil {
+		return conn, nil, errors.New("failed to generate client keypair").Base(err).AtError()
+	}
+
+	userUUID, err := uuid.ParseString(h.clientID)
+	if err != nil {
+		return conn, nil, errors.New("invalid client UUID").Base(err).AtError()
+	}
+
+	var nonce [16]byte
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return conn, nil, errors.New("failed to generate nonce").Base(err).AtError()
+	}
+
+	clientHS := &reflex.ClientHandshake{
+		PublicKey: clientPubKey,
+		UserID:    userUUID,
+		Timestamp: time.Now().Unix(),
+		Nonce:     nonce,
+	}
+
+	if _, err := conn.Write(reflex.MarshalClientHandshake(clientHS)); err != nil {
+		return conn, nil, errors.New("failed to send client handshake").Base(err).AtWarning()
+	}
+
+	// Read server handshake response
+	serverHSData := make([]byte, 64)
+	if _, err := io.ReadFull(conn, serverHSData); err != nil {
+		return conn, nil, errors.New("failed to read server handshake").Base(err).AtWarning()
+	}
+
+	serverHS, err := reflex.UnmarshalServerHandshake(serverHSData)
+	if err != nil {
+		return conn, nil, errors.New("invalid server handshake").Base(err).AtWarning()
+	}
+
+	// Derive session key
+	sharedSecret, err := reflex.DeriveSharedSecret(clientPrivKey, serverHS.PublicKey)
+	if err != nil {
+		return conn, nil, errors.New("key exchange failed").Base(err).AtError()
+	}
+	sessionKey, err := reflex.DeriveSessionKey(sharedSecret, nonce[:])
+	if err != nil {
+		return conn, nil, errors.New("session key derivation failed").Base(err).AtError()
+	}
+
+	sess, err := reflex.NewSession(sessionKey)
+	if err != nil {
+		return conn, nil, errors.New("failed to create session").Base(err).AtError()
+	}
+	return conn, sess, nil
+}
+
+// SpeedTest measures the download of size bytes of test data from the
+// server. It runs on the most recent session currently carrying traffic, so
+// the result reflects the link as it is being used; the test data is shaped
+// with the same framing and morph profile as that session's regular traffic.
+// Only when no session is open does it handshake a dedicated one.
+//
+// The server inbound must have speed tests enabled. Servers that predate
+// mid-session speed tests drop the session on the request, taking its
+// traffic with it.
+func (h *Handler) SpeedTest(ctx context.Context, dialer internet.Dialer, size uint64) (*reflex.SpeedTestResult, error) {
+	if live := h.latestLiveSession(); live != nil {
+		return live.speedTest(ctx, size)
+	}
+
+	conn, sess, err := h.connect(ctx, dialer)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = conn.Close() }()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return nil, errors.New("unable to set speed test deadline").Base(err)
+		}
+	}
+	return reflex.RunSpeedTest(sess, conn, size)
+}
+
+// speedTest sends a SPEEDTEST request on the live session and waits for
+// Process to feed the meter with the answer.
+func (s *liveSession) speedTest(ctx context.Context, size uint64) (*reflex.SpeedTestResult, error) {
+	meter := reflex.NewSpeedTestMeter()
+	if !s.meter.CompareAndSwap(nil, meter) {
+		return nil, errors.New("a speed test is already running on this session")
+	}
+	defer s.meter.CompareAndSwap(meter, nil)
+
+	if err := s.sess.WriteFrame(s.conn, reflex.FrameTypeSpeedTest, reflex.EncodeSpeedTest(size)); err != nil {
+		return nil, errors.New("failed to send speed test request").Base(err)
+	}
+	select {
+	case <-meter.Done():
+		return meter.Result()
+	case <-ctx.Done():
+		return nil, errors.New("speed test interrupted").Base(ctx.Err())
+	}
+}
+
+func (h *Handler) addLiveSession(s *liveSession) {
+	h.liveMu.Lock()
+	defer h.liveMu.Unlock()
+	h.live = append(h.live, s)
+}
+
+// removeLiveSession forgets s once Process is done with it and fails a speed
+// test still waiting on it.
+func (h *Handler) removeLiveSession(s *liveSession) {
+	h.liveMu.Lock()
+	for i, l := range h.live {
+		if l == s {
+			h.live = append(h.live[:i], h.live[i+1:]...)
+			break
+		}
+	}
+	h.liveMu.Unlock()
+
+	if meter := s.meter.Load(); meter != nil {
+		meter.Fail(errors.New("session ended during speed test"))
+	}
+}
+
+func (h *Handler) latestLiveSession() *liveSession {
+	h.liveMu.Lock()
+	defer h.liveMu.Unlock()
+	if len(h.live) == 0 {
+		return nil
+	}
+	return h.live[len(h.live)-1]
+}
+
 // marshalDestination encodes a destination as [addrType(1)] [addr] [port(2)].
 func marshalDestination(dest net.Destination) []byte {
 	var data []byte
@@ -1,13 +1,124 @@
 package outbound
 
 import (
+	"context"
 	"encoding/binary"
+	"errors"
 	"net"
 	"testing"
+	"time"
 
+	apppolicy "github.com/xtls/xray-core/app/policy"
+	"github.com/xtls/xray-core/common"
+	"github.com/xtls/xray-core/common/buf"
 	xnet "github.com/xtls/xray-core/common/net"
+	"github.com/xtls/xray-core/common/serial"
+	"github.com/xtls/xray-core/common/session"
+	"github.com/xtls/xray-core/core"
+	"github.com/xtls/xray-core/features/policy"
+	"github.com/xtls/xray-core/proxy/reflex"
+	reflexinbound "github.com/xtls/xray-core/proxy/reflex/inbound"
+	"github.com/xtls/xray-core/proxy/reflex/internal/reflextest"
+	"github.com/xtls/xray-core/transport"
+	"github.com/xtls/xray-core/transport/internet/stat"
+	"github.com/xtls/xray-core/transport/pipe"
 )
 
+const testClientID = "b831381d-6324-4d53-ad4f-8cda48b30811"
+
+// pipeDialer hands out one end of an in-memory connection.
+type pipeDialer struct {
+	conn net.Conn
+}
+
+func (d *pipeDialer) Dial(ctx context.Context, dest xnet.Destination) (stat.Connection, error) {
+	return d.conn, nil
+}
+
+func (d *pipeDialer) DestIpAddress() net.IP {
+	return nil
+}
+
+func (d *pipeDialer) SetOutboundGateway(ctx context.Context, ob *session.Outbound) {}
+
+func newTestHandler() *Handler {
+	return &Handler{
+		serverAddress: xnet.LocalHostIP,
+		serverPort:    443,
+		clientID:      testClientID,
+		policyManager: policy.DefaultManager{},
+	}
+}
+
+// runProcess tunnels a request through h to a server driven by serve and
+// returns everything the application reads back, up to the final error.
+func runProcess(t *testing.T, h *Handler, serve func(*reflex.Session, net.Conn) error) ([]byte, error) {
+	t.Helper()
+
+	clientConn, serverConn := net.Pipe()
+	defer func() { _ = serverConn.Close() }()
+
+	serverErr := make(chan error, 1)
+	go func() {
+		sess, _, err := reflextest.Accept(serverConn)
+		if err == nil {
+			err = serve(sess, serverConn)
+		}
+		serverErr <- err
+	}()
+
+	uplinkReader, uplinkWriter := pipe.New()
+	downlinkReader, downlinkWriter := pipe.New()
+	common.Must(uplinkWriter.WriteMultiBuffer(buf.MultiBuffer{buf.FromBytes([]byte("GET / HTTP/1.1\r\n\r\n"))}))
+
+	ctx := session.ContextWithOutbounds(context.Background(), []*session.Outbound{{
+		Target: xnet.TCPDestination(xnet.DomainAddress("example.com"), 80),
+	}})
+	link := &transport.Link{Reader: uplinkReader, Writer: downlinkWriter}
+	if err := h.Process(ctx, link, &pipeDialer{conn: clientConn}); err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+	if err := <-serverErr; err != nil {
+		t.Fatalf("server failed: %v", err)
+	}
+	// proxyman closes the writer after a successful Process.
+	common.Must(common.Close(downlinkWriter))
+
+	var data []byte
+	for {
+		mb, err := downlinkReader.ReadMultiBuffer()
+		for _, b := range mb {
+			data = append(data, b.Bytes()...)
+		}
+		buf.ReleaseMulti(mb)
+		if err != nil {
+			return data, err
+		}
+	}
+}
+
+func TestProcessDeliversServerCloseReason(t *testing.T) {
+	data, err := runProcess(t, newTestHandler(), func(sess *reflex.Session, conn net.Conn) error {
+		for _, chunk := range []string{"hello ", "world"} {
+			if err := sess.WriteFrame(conn, reflex.FrameTypeData, []byte(chunk)); err != nil {
+				return err
+			}
+		}
+		return sess.WriteCloseFrameWithReason(conn, reflex.CloseReasonGoAway, 7)
+	})
+
+	if string(data) != "hello world" {
+		t.Fatalf("expected the full response before the close reason, got %q", data)
+	}
+	var closeErr *reflex.CloseError
+	if !errors.As(err, &closeErr) {
+		t.Fatalf("expected *reflex.CloseError on the link reader, got %v", err)
+	}
+	if closeErr.Reason != reflex.CloseReasonGoAway || closeErr.Code != 7 {
+		t.Fatalf("unexpected close error %+v", closeErr)
+	}
+}
+
 func TestMarshalDestinationIPv4(t *testing.T) {
 	dest := xnet.TCPDestination(xnet.IPAddress(net.ParseIP("192.168.1.1").To4()), 8080)
 	data := marshalDestination(dest)
@@ -101,3 +212,188 @@ func TestMarshalDestinationLongDomain(t *testing.T) {
 		t.Fatalf("domain length mismatch: got %d, want %d", data[1], len(longDomain))
 	}
 }
+
+func TestProcessReportsForwardedOffsetOnDrop(t *testing.T) {
+	h := newTestHandler()
+	h.rangeHints = true
+
+	data, err := runProcess(t, h, func(sess *reflex.Session, conn net.Conn) error {
+		optIn, err := sess.ReadFrame(conn)
+		if err != nil {
+			return err
+		}
+		if optIn.Type != reflex.FrameTypeRangeHint {
+			return errors.New("expected the range hint opt-in")
+		}
+		if err := sess.WriteFrame(conn, reflex.FrameTypeData, []byte("hello")); err != nil {
+			return err
+		}
+		if err := sess.WriteRangeHint(conn, 5); err != nil {
+			return err
+		}
+		// Drop the connection in the middle of the next frame.
+		if _, err := conn.Write([]byte{0x00}); err != nil {
+			return err
+		}
+		return conn.Close()
+	})
+
+	if string(data) != "hello" {
+		t.Fatalf("expected the data received before the drop, got %q", data)
+	}
+	var interruptedErr *reflex.InterruptedError
+	if !errors.As(err, &interruptedErr) {
+		t.Fatalf("expected *reflex.InterruptedError on the link reader, got %v", err)
+	}
+	if interruptedErr.Offset != 5 {
+		t.Fatalf("expected forwarded offset 5, got %d", interruptedErr.Offset)
+	}
+}
+
+// silentDispatcher hands the inbound a target that never answers.
+type silentDispatcher struct{}
+
+func (silentDispatcher) Type() interface{} { return nil }
+func (silentDispatcher) Start() error      { return nil }
+func (silentDispatcher) Close() error      { return nil }
+
+func (silentDispatcher) Dispatch(ctx context.Context, dest xnet.Destination) (*transport.Link, error) {
+	reader, _ := pipe.New()
+	_, writer := pipe.New()
+	return &transport.Link{Reader: reader, Writer: writer}, nil
+}
+
+func (silentDispatcher) DispatchLink(ctx context.Context, dest xnet.Destination, link *transport.Link) error {
+	return nil
+}
+
+func TestInboundIdleTimeoutReachesOutboundLink(t *testing.T) {
+	v, err := core.New(&core.Config{
+		App: []*serial.TypedMessage{
+			serial.ToTypedMessage(&apppolicy.Config{
+				Level: map[uint32]*apppolicy.Policy{
+					0: {Timeout: &apppolicy.Policy_Timeout{
+						ConnectionIdle: &apppolicy.Second{Value: 1},
+					}},
+				},
+			}),
+		},
+	})
+	common.Must(err)
+	defer v.Close()
+
+	ctx := context.WithValue(context.Background(), core.XrayKey(1), v)
+	server, err := reflexinbound.New(ctx, &reflex.InboundConfig{
+		Clients: []*reflex.User{{Id: testClientID}},
+	})
+	common.Must(err)
+
+	clientConn, serverConn := net.Pipe()
+	defer func() { _ = serverConn.Close() }()
+	go func() {
+		_ = server.Process(ctx, xnet.Network_TCP, serverConn, silentDispatcher{})
+		_ = serverConn.Close()
+	}()
+
+	uplinkReader, uplinkWriter := pipe.New()
+	downlinkReader, downlinkWriter := pipe.New()
+	common.Must(uplinkWriter.WriteMultiBuffer(buf.MultiBuffer{buf.FromBytes([]byte("GET / HTTP/1.1\r\n\r\n"))}))
+
+	outCtx := session.ContextWithOutbounds(context.Background(), []*session.Outbound{{
+		Target: xnet.TCPDestination(xnet.DomainAddress("example.com"), 80),
+	}})
+	link := &transport.Link{Reader: uplinkReader, Writer: downlinkWriter}
+	if err := newTestHandler().Process(outCtx, link, &pipeDialer{conn: clientConn}); err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+
+	_, err = downlinkReader.ReadMultiBuffer()
+	var closeErr *reflex.CloseError
+	if !errors.As(err, &closeErr) {
+		t.Fatalf("expected *reflex.CloseError on the link reader, got %v", err)
+	}
+	if closeErr.Reason != reflex.CloseReasonIdleTimeout {
+		t.Fatalf("expected an idle timeout, got %v", closeErr.Reason)
+	}
+}
+
+// startLiveSession tunnels a request through h to a real inbound built from
+// config and returns once h has a live session to the server.
+func startLiveSession(t *testing.T, h *Handler, config *reflex.InboundConfig) {
+	t.Helper()
+
+	v, err := core.New(&core.Config{
+		App: []*serial.TypedMessage{serial.ToTypedMessage(&apppolicy.Config{})},
+	})
+	common.Must(err)
+	t.Cleanup(func() { _ = v.Close() })
+
+	ctx := context.WithValue(context.Background(), core.XrayKey(1), v)
+	config.Clients = []*reflex.User{{Id: testClientID}}
+	server, err := reflexinbound.New(ctx, config)
+	common.Must(err)
+
+	clientConn, serverConn := net.Pipe()
+	go func() {
+		_ = server.Process(ctx, xnet.Network_TCP, serverConn, silentDispatcher{})
+		_ = serverConn.Close()
+	}()
+
+	uplinkReader, uplinkWriter := pipe.New()
+	_, downlinkWriter := pipe.New()
+	common.Must(uplinkWriter.WriteMultiBuffer(buf.MultiBuffer{buf.FromBytes([]byte("GET / HTTP/1.1\r\n\r\n"))}))
+
+	outCtx := session.ContextWithOutbounds(context.Background(), []*session.Outbound{{
+		Target: xnet.TCPDestination(xnet.DomainAddress("example.com"), 80),
+	}})
+	done := make(chan struct{})
+	go func() {
+		_ = h.Process(outCtx, &transport.Link{Reader: uplinkReader, Writer: downlinkWriter}, &pipeDialer{conn: clientConn})
+		close(done)
+	}()
+	t.Cleanup(func() {
+		_ = clientConn.Close()
+		<-done
+	})
+
+	for h.latestLiveSession() == nil {
+		select {
+		case <-done:
+			t.Fatal("Process ended before the session went live")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+func TestSpeedTestRunsOnLiveSession(t *testing.T) {
+	h := newTestHandler()
+	startLiveSession(t, h, &reflex.InboundConfig{
+		SpeedTest: &reflex.SpeedTestSettings{Enabled: true},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	// No dialer: the test must reuse the live session.
+	const size = 64 << 10
+	result, err := h.SpeedTest(ctx, nil, size)
+	if err != nil {
+		t.Fatalf("SpeedTest failed: %v", err)
+	}
+	if result.PayloadBytes != size {
+		t.Fatalf("expected %d payload bytes, got %d", size, result.PayloadBytes)
+	}
+}
+
+func TestRefusedSpeedTestKeepsLiveSession(t *testing.T) {
+	h := newTestHandler()
+	startLiveSession(t, h, &reflex.InboundConfig{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if _, err := h.SpeedTest(ctx, nil, 64<<10); err == nil {
+		t.Fatal("expected the server to refuse the speed test")
+	}
+	if h.latestLiveSession() == nil {
+		t.Fatal("a refused speed test must not end the session")
+	}
+}
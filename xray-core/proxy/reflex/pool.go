@@ -0,0 +1,268 @@
+package reflex
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/xtls/xray-core/common/errors"
+)
+
+const (
+	cryptoQueueSize = 256
+	cryptoLaneBatch = 16
+)
+
+// CryptoPool is a fixed-size set of workers that performs AEAD open/seal on
+// behalf of sessions. Each worker owns a queue of lanes; idle workers steal
+// lanes from their siblings so that a burst on one queue does not leave the
+// remaining cores idle. Bounding the number of goroutines doing crypto keeps
+// very high connection counts from oversubscribing the scheduler.
+type CryptoPool struct {
+	// queues only ever grows; workers pick up the longer list on their next
+	// pass, so it is swapped atomically instead of being locked.
+	queues atomic.Pointer[[]chan *cryptoLane]
+	wake   chan struct{}
+	done   chan struct{}
+	next   atomic.Uint32
+	growMu sync.Mutex
+	once   sync.Once
+	wg     sync.WaitGroup
+}
+
+// NewCryptoPool starts a pool with the given number of workers.
+func NewCryptoPool(workers int) *CryptoPool {
+	if workers <= 0 {
+		workers = 1
+	}
+	p := &CryptoPool{
+		wake: make(chan struct{}, cryptoQueueSize),
+		done: make(chan struct{}),
+	}
+	p.queues.Store(&[]chan *cryptoLane{})
+	p.grow(workers)
+	return p
+}
+
+// grow adds workers until the pool has at least the given number.
+func (p *CryptoPool) grow(workers int) {
+	p.growMu.Lock()
+	defer p.growMu.Unlock()
+
+	old := p.queueList()
+	if workers <= len(old) {
+		return
+	}
+	select {
+	case <-p.done:
+		return
+	default:
+	}
+
+	queues := make([]chan *cryptoLane, workers)
+	copy(queues, old)
+	for i := len(old); i < workers; i++ {
+		queues[i] = make(chan *cryptoLane, cryptoQueueSize)
+	}
+	p.queues.Store(&queues)
+
+	p.wg.Add(workers - len(old))
+	for i := len(old); i < workers; i++ {
+		go p.worker(i)
+	}
+}
+
+func (p *CryptoPool) queueList() []chan *cryptoLane {
+	return *p.queues.Load()
+}
+
+// ErrCryptoPoolClosed is returned for work submitted to, or still pending
+// on, a closed pool.
+var ErrCryptoPoolClosed = errors.New("crypto pool closed")
+
+var (
+	sharedPoolMu sync.Mutex
+	sharedPool   *CryptoPool
+)
+
+// SharedCryptoPool returns the process-wide pool, grown to at least the given
+// number of workers. The pool is sized by the largest count requested so
+// far and never shrinks, so several inbounds never add up to more crypto
+// goroutines than the largest of them configured. Inbound handlers are not
+// closed when the instance reloads, so sharing the pool also avoids leaking
+// workers.
+func SharedCryptoPool(workers int) *CryptoPool {
+	sharedPoolMu.Lock()
+	defer sharedPoolMu.Unlock()
+
+	if sharedPool == nil {
+		sharedPool = NewCryptoPool(workers)
+	} else {
+		sharedPool.grow(workers)
+	}
+	return sharedPool
+}
+
+// Workers returns the number of workers in the pool.
+func (p *CryptoPool) Workers() int {
+	return len(p.queueList())
+}
+
+// Close stops all workers. Lanes still queued are dropped and their pending
+// tasks fail with ErrCryptoPoolClosed.
+func (p *CryptoPool) Close() error {
+	// Holding growMu keeps grow from adding workers while Close waits.
+	p.growMu.Lock()
+	p.once.Do(func() {
+		close(p.done)
+	})
+	p.growMu.Unlock()
+	p.wg.Wait()
+	return nil
+}
+
+// newLane creates an ordered task queue bound to one of the workers.
+func (p *CryptoPool) newLane() *cryptoLane {
+	home := int(p.next.Add(1)-1) % p.Workers()
+	return &cryptoLane{pool: p, home: home}
+}
+
+func (p *CryptoPool) schedule(l *cryptoLane) error {
+	if !p.trySchedule(l) {
+		select {
+		case p.queueList()[l.home] <- l:
+		case <-p.done:
+			return ErrCryptoPoolClosed
+		}
+	}
+	p.notify()
+	return nil
+}
+
+// trySchedule queues the lane on its home worker, or on the first sibling
+// with room when the home queue is saturated. It never blocks.
+func (p *CryptoPool) trySchedule(l *cryptoLane) bool {
+	queues := p.queueList()
+	for i := 0; i < len(queues); i++ {
+		select {
+		case queues[(l.home+i)%len(queues)] <- l:
+			return true
+		default:
+		}
+	}
+	return false
+}
+
+func (p *CryptoPool) notify() {
+	select {
+	case p.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (p *CryptoPool) worker(id int) {
+	defer p.wg.Done()
+
+	own := p.queueList()[id]
+	for {
+		if l := p.take(id); l != nil {
+			l.run()
+			continue
+		}
+		select {
+		case l := <-own:
+			l.run()
+		case <-p.wake:
+		case <-p.done:
+			return
+		}
+	}
+}
+
+// take returns a lane from the worker's own queue, or steals one from a
+// sibling when the own queue is empty.
+func (p *CryptoPool) take(id int) *cryptoLane {
+	queues := p.queueList()
+	for i := 0; i < len(queues); i++ {
+		select {
+		case l := <-queues[(id+i)%len(queues)]:
+			return l
+		default:
+		}
+	}
+	return nil
+}
+
+// cryptoLane serializes the tasks of a single session. A lane is queued on
+// the pool at most once at a time, so its tasks never run concurrently and
+// always run in submission order, regardless of which worker picks it up.
+type cryptoLane struct {
+	pool      *CryptoPool
+	home      int
+	mu        sync.Mutex
+	tasks     []func()
+	scheduled bool
+}
+
+func (l *cryptoLane) submit(task func()) error {
+	select {
+	case <-l.pool.done:
+		return ErrCryptoPoolClosed
+	default:
+	}
+
+	l.mu.Lock()
+	l.tasks = append(l.tasks, task)
+	if l.scheduled {
+		l.mu.Unlock()
+		return nil
+	}
+	l.scheduled = true
+	l.mu.Unlock()
+
+	return l.pool.schedule(l)
+}
+
+// do runs task on the pool and waits for it to complete. If the pool is
+// closed first, do returns ErrCryptoPoolClosed and the task may never run.
+func (l *cryptoLane) do(task func()) error {
+	done := make(chan struct{})
+	if err := l.submit(func() {
+		task()
+		close(done)
+	}); err != nil {
+		return err
+	}
+	select {
+	case <-done:
+		return nil
+	case <-l.pool.done:
+		return ErrCryptoPoolClosed
+	}
+}
+
+func (l *cryptoLane) run() {
+	for {
+		for i := 0; i < cryptoLaneBatch; i++ {
+			l.mu.Lock()
+			if len(l.tasks) == 0 {
+				l.scheduled = false
+				l.mu.Unlock()
+				return
+			}
+			task := l.tasks[0]
+			l.tasks[0] = nil
+			l.tasks = l.tasks[1:]
+			l.mu.Unlock()
+
+			task()
+		}
+
+		// Yield to other lanes after a full batch so one busy session cannot
+		// monopolize a worker. Workers must never block on a full queue, so
+		// keep draining the lane if there is nowhere to put it.
+		if l.pool.trySchedule(l) {
+			l.pool.notify()
+			return
+		}
+	}
+}
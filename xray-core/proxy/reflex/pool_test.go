@@ -0,0 +1,229 @@
+package reflex
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestCryptoPoolLaneOrdering(t *testing.T) {
+	pool := NewCryptoPool(4)
+	defer pool.Close()
+
+	lane := pool.newLane()
+	var (
+		mu  sync.Mutex
+		got []int
+		wg  sync.WaitGroup
+	)
+	const n = 1000
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		i := i
+		lane.submit(func() {
+			mu.Lock()
+			got = append(got, i)
+			mu.Unlock()
+			wg.Done()
+		})
+	}
+	wg.Wait()
+
+	for i, v := range got {
+		if v != i {
+			t.Fatalf("task %d ran out of order (got %d)", i, v)
+		}
+	}
+}
+
+func TestCryptoPoolLanesShareWorkers(t *testing.T) {
+	const workers = 2
+	pool := NewCryptoPool(workers)
+	defer pool.Close()
+
+	var (
+		running atomic.Int32
+		peak    atomic.Int32
+		ran     atomic.Int32
+		wg      sync.WaitGroup
+	)
+	for l := 0; l < 50; l++ {
+		lane := pool.newLane()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				err := lane.do(func() {
+					n := running.Add(1)
+					for {
+						p := peak.Load()
+						if n <= p || peak.CompareAndSwap(p, n) {
+							break
+						}
+					}
+					ran.Add(1)
+					running.Add(-1)
+				})
+				if err != nil {
+					t.Errorf("do failed: %v", err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := ran.Load(); got != 50*100 {
+		t.Fatalf("expected %d tasks to run, got %d", 50*100, got)
+	}
+	if got := peak.Load(); got > workers {
+		t.Fatalf("expected at most %d concurrent tasks, got %d", workers, got)
+	}
+}
+
+func TestCryptoPoolCloseUnblocksPending(t *testing.T) {
+	pool := NewCryptoPool(1)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	go pool.newLane().do(func() {
+		close(started)
+		<-release
+	})
+	<-started
+
+	// The only worker is busy, so this task stays queued until Close.
+	pending := make(chan error, 1)
+	go func() {
+		pending <- pool.newLane().do(func() {})
+	}()
+
+	closed := make(chan struct{})
+	go func() {
+		pool.Close()
+		close(closed)
+	}()
+
+	if err := <-pending; err != ErrCryptoPoolClosed {
+		t.Fatalf("expected ErrCryptoPoolClosed, got %v", err)
+	}
+	close(release)
+	<-closed
+
+	if err := pool.newLane().do(func() {}); err != ErrCryptoPoolClosed {
+		t.Fatalf("expected ErrCryptoPoolClosed after Close, got %v", err)
+	}
+}
+
+func TestSessionWithCryptoPool(t *testing.T) {
+	pool := NewCryptoPool(4)
+	defer pool.Close()
+
+	key := makeTestSessionKey()
+	writer, _ := NewSession(key)
+	reader, _ := NewSession(key)
+	writer.SetCryptoPool(pool)
+	reader.SetCryptoPool(pool)
+
+	var buf bytes.Buffer
+	for i := 0; i < 100; i++ {
+		if err := writer.WriteFrame(&buf, FrameTypeData, []byte(fmt.Sprintf("frame-%d", i))); err != nil {
+			t.Fatalf("WriteFrame %d failed: %v", i, err)
+		}
+	}
+
+	for i := 0; i < 100; i++ {
+		frame, err := reader.ReadFrame(&buf)
+		if err != nil {
+			t.Fatalf("ReadFrame %d failed: %v", i, err)
+		}
+		if want := fmt.Sprintf("frame-%d", i); string(frame.Payload) != want {
+			t.Fatalf("frame %d: got %q, want %q", i, frame.Payload, want)
+		}
+	}
+}
+
+func TestSessionPoolInterop(t *testing.T) {
+	pool := NewCryptoPool(2)
+	defer pool.Close()
+
+	key := makeTestSessionKey()
+	writer, _ := NewSession(key)
+	reader, _ := NewSession(key)
+	writer.SetCryptoPool(pool)
+
+	var buf bytes.Buffer
+	if err := writer.WriteFrame(&buf, FrameTypeData, []byte("pooled")); err != nil {
+		t.Fatalf("WriteFrame failed: %v", err)
+	}
+	frame, err := reader.ReadFrame(&buf)
+	if err != nil {
+		t.Fatalf("inline ReadFrame failed: %v", err)
+	}
+	if string(frame.Payload) != "pooled" {
+		t.Fatalf("unexpected payload %q", frame.Payload)
+	}
+}
+
+// resetSharedCryptoPool gives the test a fresh process-wide pool and restores
+// the previous one afterwards.
+func resetSharedCryptoPool(t *testing.T) {
+	sharedPoolMu.Lock()
+	previous := sharedPool
+	sharedPool = nil
+	sharedPoolMu.Unlock()
+
+	t.Cleanup(func() {
+		sharedPoolMu.Lock()
+		defer sharedPoolMu.Unlock()
+		if sharedPool != nil {
+			sharedPool.Close()
+		}
+		sharedPool = previous
+	})
+}
+
+func TestSharedCryptoPool(t *testing.T) {
+	resetSharedCryptoPool(t)
+
+	a := SharedCryptoPool(3)
+	b := SharedCryptoPool(5)
+	if a != b {
+		t.Fatal("expected a single shared pool regardless of worker count")
+	}
+	if a.Workers() != 5 {
+		t.Fatalf("expected the pool to grow to the largest count, got %d", a.Workers())
+	}
+	if SharedCryptoPool(2).Workers() != 5 {
+		t.Fatal("the shared pool must not shrink")
+	}
+}
+
+func TestCryptoPoolGrowKeepsWorking(t *testing.T) {
+	pool := NewCryptoPool(1)
+	defer pool.Close()
+
+	before := pool.newLane()
+	pool.grow(4)
+	after := pool.newLane()
+
+	for _, lane := range []*cryptoLane{before, after} {
+		ran := false
+		if err := lane.do(func() { ran = true }); err != nil || !ran {
+			t.Fatalf("lane did not run after grow: %v", err)
+		}
+	}
+}
+
+func TestSessionSeparateLanes(t *testing.T) {
+	pool := NewCryptoPool(2)
+	defer pool.Close()
+
+	sess, _ := NewSession(makeTestSessionKey())
+	sess.SetCryptoPool(pool)
+	if sess.readLane == nil || sess.readLane == sess.writeLane {
+		t.Fatal("reads and writes should use separate lanes")
+	}
+}
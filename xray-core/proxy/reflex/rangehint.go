@@ -0,0 +1,71 @@
+package reflex
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+const (
+	// RangeHintInterval is how many uplink bytes the server forwards to the
+	// target between unsolicited range hints, so the client's last hint
+	// stays recent when the connection drops without warning.
+	RangeHintInterval = 256 << 10
+
+	rangeHintPayloadSize = 8
+)
+
+// InterruptedError is delivered to the application when a session that opted
+// into range hints breaks without a CLOSE frame. Offset is the last count of
+// uplink bytes the server reported as forwarded to the target. It counts raw
+// tunneled bytes, including any protocol headers or TLS records the client
+// sent through the tunnel, so it is not a position in an HTTP body and
+// cannot be used as a Range value. The session is not resumed: the client has
+// to open a new one and decide for itself what to send again.
+type InterruptedError struct {
+	Offset uint64
+	Err    error
+}
+
+func (e *InterruptedError) Error() string {
+	return fmt.Sprintf("reflex: session interrupted after %d forwarded bytes: %v", e.Offset, e.Err)
+}
+
+func (e *InterruptedError) Unwrap() error {
+	return e.Err
+}
+
+// EncodeRangeHint creates a RANGE_HINT payload carrying the number of uplink
+// bytes the server has forwarded to the target.
+func EncodeRangeHint(offset uint64) []byte {
+	data := make([]byte, rangeHintPayloadSize)
+	binary.BigEndian.PutUint64(data, offset)
+	return data
+}
+
+// DecodeRangeHint extracts the offset from a RANGE_HINT frame sent by the
+// server. It returns false for the empty opt-in frame sent by clients.
+func DecodeRangeHint(frame *Frame) (uint64, bool) {
+	if frame.Type != FrameTypeRangeHint || len(frame.Payload) < rangeHintPayloadSize {
+		return 0, false
+	}
+	return binary.BigEndian.Uint64(frame.Payload), true
+}
+
+// RequestRangeHints opts the session into the RANGE_HINT extension. The
+// server answers with the number of uplink bytes it has forwarded so far,
+// repeats it every RangeHintInterval forwarded bytes and once more before an
+// idle-timeout CLOSE. This tells the client how much of its upload actually
+// left the server; it is progress reporting only and does not make the
+// session resumable.
+//
+// Servers without the extension reject the frame as an unknown frame type
+// and drop the session, so only opt in against servers known to support it.
+func (s *Session) RequestRangeHints(writer io.Writer) error {
+	return s.WriteFrame(writer, FrameTypeRangeHint, []byte{})
+}
+
+// WriteRangeHint sends the number of uplink bytes forwarded so far.
+func (s *Session) WriteRangeHint(writer io.Writer, offset uint64) error {
+	return s.WriteFrame(writer, FrameTypeRangeHint, EncodeRangeHint(offset))
+}
@@ -0,0 +1,74 @@
+package reflex
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestRangeHintRoundTrip(t *testing.T) {
+	key := makeTestSessionKey()
+	writer, _ := NewSession(key)
+	reader, _ := NewSession(key)
+
+	var buf bytes.Buffer
+	if err := writer.WriteRangeHint(&buf, 1<<33+42); err != nil {
+		t.Fatalf("WriteRangeHint failed: %v", err)
+	}
+
+	frame, err := reader.ReadFrame(&buf)
+	if err != nil {
+		t.Fatalf("ReadFrame failed: %v", err)
+	}
+	if frame.Type != FrameTypeRangeHint {
+		t.Fatalf("expected RANGE_HINT frame, got type %d", frame.Type)
+	}
+	offset, ok := DecodeRangeHint(frame)
+	if !ok {
+		t.Fatal("expected a range hint offset")
+	}
+	if offset != 1<<33+42 {
+		t.Fatalf("unexpected offset %d", offset)
+	}
+}
+
+func TestRequestRangeHintsHasNoOffset(t *testing.T) {
+	key := makeTestSessionKey()
+	writer, _ := NewSession(key)
+	reader, _ := NewSession(key)
+
+	var buf bytes.Buffer
+	if err := writer.RequestRangeHints(&buf); err != nil {
+		t.Fatalf("RequestRangeHints failed: %v", err)
+	}
+
+	frame, err := reader.ReadFrame(&buf)
+	if err != nil {
+		t.Fatalf("ReadFrame failed: %v", err)
+	}
+	if frame.Type != FrameTypeRangeHint {
+		t.Fatalf("expected RANGE_HINT frame, got type %d", frame.Type)
+	}
+	if _, ok := DecodeRangeHint(frame); ok {
+		t.Fatal("opt-in frame must not carry an offset")
+	}
+}
+
+func TestDecodeRangeHintWrongType(t *testing.T) {
+	frame := &Frame{Type: FrameTypeData, Payload: EncodeRangeHint(10)}
+	if _, ok := DecodeRangeHint(frame); ok {
+		t.Fatal("DATA frame must not be decoded as a range hint")
+	}
+}
+
+func TestInterruptedErrorUnwrap(t *testing.T) {
+	err := error(&InterruptedError{Offset: 42, Err: io.ErrUnexpectedEOF})
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatal("InterruptedError should unwrap to its cause")
+	}
+	var interruptedErr *InterruptedError
+	if !errors.As(err, &interruptedErr) || interruptedErr.Offset != 42 {
+		t.Fatalf("unexpected interrupted error %v", err)
+	}
+}
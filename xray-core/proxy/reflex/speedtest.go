@@ -0,0 +1,227 @@
+package reflex
+
+import (
+	"encoding/binary"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/xtls/xray-core/common/errors"
+)
+
+const (
+	// MaxSpeedTestBytes caps how much padding a server streams for a single
+	// speed test request.
+	MaxSpeedTestBytes = 256 << 20
+
+	// DefaultSpeedTestInterval is the minimum time between two speed tests
+	// by the same user when the inbound does not configure one.
+	DefaultSpeedTestInterval = time.Minute
+
+	speedTestPayloadSize = 8
+)
+
+// SpeedTestLimiter allows each user one speed test per interval, so that a
+// single client cannot keep the server streaming padding.
+type SpeedTestLimiter struct {
+	interval time.Duration
+	mu       sync.Mutex
+	last     map[string]time.Time
+}
+
+// NewSpeedTestLimiter creates a limiter with the given per-user interval.
+func NewSpeedTestLimiter(interval time.Duration) *SpeedTestLimiter {
+	return &SpeedTestLimiter{
+		interval: interval,
+		last:     make(map[string]time.Time),
+	}
+}
+
+// Allow reports whether user may start a speed test now and, if so, counts
+// it against the user's interval.
+func (l *SpeedTestLimiter) Allow(user string) bool {
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if last, ok := l.last[user]; ok && now.Sub(last) < l.interval {
+		return false
+	}
+	l.last[user] = now
+	return true
+}
+
+// SpeedTestResult holds the client-side measurement of an in-band speed test.
+// Only the test's own frames are counted; regular traffic sharing the session
+// is not.
+type SpeedTestResult struct {
+	// PayloadBytes is the test payload received, excluding framing overhead.
+	PayloadBytes uint64
+	// WireBytes is everything received for the test, including frame headers
+	// and AEAD tags.
+	WireBytes uint64
+	Duration  time.Duration
+}
+
+// Mbps returns the measured throughput on the wire in megabits per second.
+func (r *SpeedTestResult) Mbps() float64 {
+	if r.Duration <= 0 {
+		return 0
+	}
+	return float64(r.WireBytes) * 8 / r.Duration.Seconds() / 1e6
+}
+
+// EncodeSpeedTest creates a SPEEDTEST payload carrying a byte count.
+func EncodeSpeedTest(size uint64) []byte {
+	data := make([]byte, speedTestPayloadSize)
+	binary.BigEndian.PutUint64(data, size)
+	return data
+}
+
+// DecodeSpeedTest extracts the byte count from a SPEEDTEST frame.
+func DecodeSpeedTest(frame *Frame) (uint64, bool) {
+	if frame.Type != FrameTypeSpeedTest || len(frame.Payload) < speedTestPayloadSize {
+		return 0, false
+	}
+	return binary.BigEndian.Uint64(frame.Payload), true
+}
+
+// ServeSpeedTest streams size bytes of SPEEDTEST_DATA frames to the client,
+// shaped by morph when it is enabled, and ends with a SPEEDTEST frame
+// carrying the number of bytes sent. The size is capped at MaxSpeedTestBytes.
+func ServeSpeedTest(sess *Session, writer io.Writer, morph *TrafficMorph, size uint64) error {
+	if size > MaxSpeedTestBytes {
+		size = MaxSpeedTestBytes
+	}
+
+	filler := make([]byte, MaxFramePayload)
+	for sent := uint64(0); sent < size; {
+		chunk := filler
+		if remaining := size - sent; remaining < uint64(len(chunk)) {
+			chunk = chunk[:remaining]
+		}
+		var err error
+		if morph != nil && morph.Enabled {
+			err = morph.MorphWriteFrame(sess, writer, FrameTypeSpeedTestData, chunk)
+		} else {
+			err = sess.WriteFrame(writer, FrameTypeSpeedTestData, chunk)
+		}
+		if err != nil {
+			return errors.New("failed to write speed test data").Base(err)
+		}
+		sent += uint64(len(chunk))
+	}
+
+	return sess.WriteFrame(writer, FrameTypeSpeedTest, EncodeSpeedTest(size))
+}
+
+// RefuseSpeedTest tells the client that a speed test requested in the middle
+// of a session will not run. The session itself carries on.
+func (s *Session) RefuseSpeedTest(writer io.Writer) error {
+	return s.WriteFrame(writer, FrameTypeSpeedTest, []byte{})
+}
+
+// SpeedTestMeter measures a speed test from the frames of the session it
+// runs on. Whoever reads the session passes every frame to Observe, so the
+// test can share a live session with regular traffic.
+type SpeedTestMeter struct {
+	start  time.Time
+	counts SpeedTestResult
+
+	once   sync.Once
+	done   chan struct{}
+	result *SpeedTestResult
+	err    error
+}
+
+// NewSpeedTestMeter creates a meter and starts its clock. Create it right
+// before sending the SPEEDTEST request.
+func NewSpeedTestMeter() *SpeedTestMeter {
+	return &SpeedTestMeter{
+		start: time.Now(),
+		done:  make(chan struct{}),
+	}
+}
+
+// Observe accounts frame against the test and reports whether it belonged to
+// it. It must be called from the goroutine reading the session.
+func (m *SpeedTestMeter) Observe(frame *Frame) bool {
+	switch frame.Type {
+	case FrameTypeSpeedTestData:
+		m.counts.WireBytes += uint64(FrameHeaderSize) + uint64(frame.Length)
+		m.counts.PayloadBytes += uint64(len(frame.Payload))
+		return true
+	case FrameTypeSpeedTest:
+		m.counts.WireBytes += uint64(FrameHeaderSize) + uint64(frame.Length)
+		m.counts.Duration = time.Since(m.start)
+		result := m.counts
+		sent, ok := DecodeSpeedTest(frame)
+		switch {
+		case !ok:
+			m.finish(nil, errors.New("server refused the speed test; it may be disabled, rate limited or already running"))
+		case result.PayloadBytes < sent:
+			// Morphing may pad the last frame, so only a shortfall is an error.
+			m.finish(&result, errors.New("speed test incomplete: server sent ", sent, " bytes, received ", result.PayloadBytes))
+		default:
+			m.finish(&result, nil)
+		}
+		return true
+	}
+	return false
+}
+
+// Fail ends the test with err unless it has already finished.
+func (m *SpeedTestMeter) Fail(err error) {
+	m.finish(nil, err)
+}
+
+// Done is closed once the test has finished.
+func (m *SpeedTestMeter) Done() <-chan struct{} {
+	return m.done
+}
+
+// Result returns the outcome of a finished test.
+func (m *SpeedTestMeter) Result() (*SpeedTestResult, error) {
+	<-m.done
+	return m.result, m.err
+}
+
+func (m *SpeedTestMeter) finish(result *SpeedTestResult, err error) {
+	m.once.Do(func() {
+		m.result, m.err = result, err
+		close(m.done)
+	})
+}
+
+// RunSpeedTest asks the server for size bytes of test data on a freshly
+// handshaked session that carries nothing else, and measures how fast they
+// arrive. The data goes through the same framing and morph profile as regular
+// traffic.
+func RunSpeedTest(sess *Session, conn io.ReadWriter, size uint64) (*SpeedTestResult, error) {
+	meter := NewSpeedTestMeter()
+	if err := sess.WriteFrame(conn, FrameTypeSpeedTest, EncodeSpeedTest(size)); err != nil {
+		return nil, errors.New("failed to send speed test request").Base(err)
+	}
+
+	for {
+		frame, err := sess.ReadFrame(conn)
+		if err != nil {
+			return nil, errors.New("speed test interrupted").Base(err)
+		}
+		if meter.Observe(frame) {
+			select {
+			case <-meter.Done():
+				return meter.Result()
+			default:
+			}
+			continue
+		}
+		if frame.Type == FrameTypeClose {
+			if closeErr := ParseCloseFrame(frame); closeErr != nil {
+				return nil, closeErr
+			}
+			return nil, errors.New("server closed the session during speed test; speed tests may be disabled on the server")
+		}
+	}
+}
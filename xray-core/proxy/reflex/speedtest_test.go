@@ -0,0 +1,153 @@
+package reflex
+
+import (
+	"net"
+	"testing"
+	"time"
+)
+
+func runSpeedTestPair(t *testing.T, morph *TrafficMorph, size uint64) *SpeedTestResult {
+	t.Helper()
+
+	key := makeTestSessionKey()
+	clientSess, _ := NewSession(key)
+	serverSess, _ := NewSession(key)
+
+	clientConn, serverConn := net.Pipe()
+	defer func() { _ = clientConn.Close() }()
+	defer func() { _ = serverConn.Close() }()
+
+	serverErr := make(chan error, 1)
+	go func() {
+		request, err := serverSess.ReadFrame(serverConn)
+		if err != nil {
+			serverErr <- err
+			return
+		}
+		requested, ok := DecodeSpeedTest(request)
+		if !ok {
+			t.Error("server received an invalid speed test request")
+		}
+		serverErr <- ServeSpeedTest(serverSess, serverConn, morph, requested)
+	}()
+
+	result, err := RunSpeedTest(clientSess, clientConn, size)
+	if err != nil {
+		t.Fatalf("RunSpeedTest failed: %v", err)
+	}
+	if err := <-serverErr; err != nil {
+		t.Fatalf("ServeSpeedTest failed: %v", err)
+	}
+	return result
+}
+
+func TestSpeedTestRoundTrip(t *testing.T) {
+	const size = 100000
+	result := runSpeedTestPair(t, nil, size)
+
+	if result.PayloadBytes != size {
+		t.Fatalf("expected %d payload bytes, got %d", size, result.PayloadBytes)
+	}
+	if result.WireBytes <= result.PayloadBytes {
+		t.Fatal("wire bytes should include framing overhead")
+	}
+	if result.Duration <= 0 || result.Mbps() <= 0 {
+		t.Fatalf("expected a positive measurement, got %v / %f Mbps", result.Duration, result.Mbps())
+	}
+}
+
+func TestSpeedTestWithMorph(t *testing.T) {
+	morph := &TrafficMorph{
+		Profile: &TrafficProfile{
+			Name:        "Fast",
+			PacketSizes: []PacketSizeDist{{Size: 1000, Weight: 1}},
+			Delays:      []DelayDist{{Delay: 0, Weight: 1}},
+		},
+		Enabled: true,
+	}
+
+	const size = 20000
+	result := runSpeedTestPair(t, morph, size)
+
+	// The last morphed frame is padded up to the profile size.
+	if result.PayloadBytes < size {
+		t.Fatalf("expected at least %d payload bytes, got %d", size, result.PayloadBytes)
+	}
+}
+
+func TestSpeedTestFrameEncoding(t *testing.T) {
+	frame := &Frame{Type: FrameTypeSpeedTest, Payload: EncodeSpeedTest(12345)}
+	size, ok := DecodeSpeedTest(frame)
+	if !ok || size != 12345 {
+		t.Fatalf("unexpected decode result %d, %v", size, ok)
+	}
+
+	frame.Type = FrameTypePadding
+	if _, ok := DecodeSpeedTest(frame); ok {
+		t.Fatal("PADDING frame must not be decoded as a speed test")
+	}
+}
+
+func TestSpeedTestMeterIgnoresRegularFrames(t *testing.T) {
+	meter := NewSpeedTestMeter()
+	frames := []*Frame{
+		{Type: FrameTypeData, Length: 116, Payload: make([]byte, 100)},
+		{Type: FrameTypeSpeedTestData, Length: 66, Payload: make([]byte, 50)},
+		{Type: FrameTypePadding, Length: 20, Payload: make([]byte, 4)},
+		{Type: FrameTypeSpeedTestData, Length: 66, Payload: make([]byte, 50)},
+	}
+	for _, frame := range frames {
+		isTest := frame.Type == FrameTypeSpeedTestData
+		if meter.Observe(frame) != isTest {
+			t.Fatalf("unexpected Observe result for frame type %#x", frame.Type)
+		}
+	}
+	select {
+	case <-meter.Done():
+		t.Fatal("meter finished before the server ended the test")
+	default:
+	}
+
+	meter.Observe(&Frame{Type: FrameTypeSpeedTest, Length: 24, Payload: EncodeSpeedTest(100)})
+	result, err := meter.Result()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if result.PayloadBytes != 100 {
+		t.Fatalf("expected 100 payload bytes, got %d", result.PayloadBytes)
+	}
+}
+
+func TestSpeedTestMeterRefused(t *testing.T) {
+	meter := NewSpeedTestMeter()
+	if !meter.Observe(&Frame{Type: FrameTypeSpeedTest, Length: 16}) {
+		t.Fatal("refusal should belong to the test")
+	}
+	if _, err := meter.Result(); err == nil {
+		t.Fatal("expected an error for a refused speed test")
+	}
+
+	// Fail after the test finished keeps the first outcome.
+	meter.Fail(net.ErrClosed)
+	if _, err := meter.Result(); err == net.ErrClosed {
+		t.Fatal("Fail must not override a finished test")
+	}
+}
+
+func TestSpeedTestLimiter(t *testing.T) {
+	limiter := NewSpeedTestLimiter(time.Hour)
+	if !limiter.Allow("alice") {
+		t.Fatal("first speed test should be allowed")
+	}
+	if limiter.Allow("alice") {
+		t.Fatal("second speed test within the interval should be rejected")
+	}
+	if !limiter.Allow("bob") {
+		t.Fatal("limits must be tracked per user")
+	}
+
+	limiter = NewSpeedTestLimiter(0)
+	if !limiter.Allow("alice") || !limiter.Allow("alice") {
+		t.Fatal("a zero interval should not limit")
+	}
+}
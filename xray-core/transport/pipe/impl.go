@@ -39,6 +39,7 @@ type pipe struct {
 	errChan     chan error
 	option      pipeOption
 	state       state
+	closeErr    error
 }
 
 var (
@@ -68,6 +69,9 @@ func (p *pipe) getState(forRead bool) error {
 		if !p.data.IsEmpty() {
 			return nil
 		}
+		if p.closeErr != nil {
+			return p.closeErr
+		}
 		return io.EOF
 	case errord:
 		return io.ErrClosedPipe
@@ -183,6 +187,12 @@ func (p *pipe) WriteMultiBuffer(mb buf.MultiBuffer) error {
 }
 
 func (p *pipe) Close() error {
+	return p.CloseWithError(nil)
+}
+
+// CloseWithError closes the pipe like Close, but once the remaining data is
+// drained the reader gets err instead of io.EOF. A nil err behaves like Close.
+func (p *pipe) CloseWithError(err error) error {
 	p.Lock()
 	defer p.Unlock()
 
@@ -191,6 +201,7 @@ func (p *pipe) Close() error {
 	}
 
 	p.state = closed
+	p.closeErr = err
 	common.Must(p.done.Close())
 	return nil
 }
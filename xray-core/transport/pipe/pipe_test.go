@@ -72,6 +72,31 @@ func TestPipeClose(t *testing.T) {
 	}
 }
 
+func TestPipeCloseWithError(t *testing.T) {
+	pReader, pWriter := New(WithSizeLimit(1024))
+	payload := []byte{'a', 'b', 'c', 'd'}
+	b := buf.New()
+	common.Must2(b.Write(payload))
+	common.Must(pWriter.WriteMultiBuffer(buf.MultiBuffer{b}))
+
+	closeErr := errors.New("closed by peer")
+	common.Must(pWriter.CloseWithError(closeErr))
+
+	rb, err := pReader.ReadMultiBuffer()
+	common.Must(err)
+	if rb.String() != string(payload) {
+		t.Fatal("expect content ", string(payload), " but actually ", rb.String())
+	}
+
+	rb, err = pReader.ReadMultiBuffer()
+	if err != closeErr {
+		t.Fatal("expected close error, but got ", err)
+	}
+	if !rb.IsEmpty() {
+		t.Fatal("expect empty buffer, but got ", rb.String())
+	}
+}
+
 func TestPipeLimitZero(t *testing.T) {
 	pReader, pWriter := New(WithSizeLimit(0))
 	bb := buf.New()
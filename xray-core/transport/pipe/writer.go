@@ -19,6 +19,11 @@ func (w *Writer) Close() error {
 	return w.pipe.Close()
 }
 
+// CloseWithError closes the pipe. Reading from the pipe returns the remaining data, followed by err instead of io.EOF.
+func (w *Writer) CloseWithError(err error) error {
+	return w.pipe.CloseWithError(err)
+}
+
 func (w *Writer) Len() int32 {
 	return w.pipe.Len()
 }